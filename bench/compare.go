@@ -7,158 +7,353 @@ import (
 	"strings"
 )
 
-func CompareTwoRuns(before, after Run) ([]ComparisonResult, error) {
-	if len(before.Suites) != len(after.Suites) {
-		return nil, fmt.Errorf("number of suites mismatch: %d vs %d", len(before.Suites), len(after.Suites))
+type CompareOptions struct {
+	Alpha      float64
+	Policy     *Policy
+	MatchProcs bool
+	Params     map[string]string
+}
+
+func DefaultCompareOptions() CompareOptions {
+	return CompareOptions{
+		Alpha: 0.05,
 	}
+}
 
+func CompareTwoRuns(before, after Run, opts CompareOptions) ([]ComparisonResult, error) {
 	totalBenchmarks := 0
 	for _, s := range before.Suites {
 		totalBenchmarks += len(s.Benchmarks)
 	}
 	results := make([]ComparisonResult, 0, totalBenchmarks)
 
-	for i := 0; i < len(before.Suites); i++ {
-		beforeSuite := before.Suites[i]
-		afterSuite := after.Suites[i]
+	afterSuites := make(map[string]Suite, len(after.Suites))
+	for _, s := range after.Suites {
+		afterSuites[suiteKey(s)] = s
+	}
 
-		if beforeSuite.Pkg != afterSuite.Pkg {
-			return nil, fmt.Errorf("suite package mismatch at index %d: %s vs %s", i, beforeSuite.Pkg, afterSuite.Pkg)
+	matched := make(map[string]bool, len(before.Suites))
+	for _, beforeSuite := range before.Suites {
+		key := suiteKey(beforeSuite)
+		afterSuite, ok := afterSuites[key]
+		if !ok {
+			afterSuite = Suite{Pkg: beforeSuite.Pkg}
 		}
+		matched[key] = true
 
-		suiteResults := compareSuites(beforeSuite, afterSuite)
+		suiteResults := compareSuites(beforeSuite, afterSuite, opts)
+		results = append(results, suiteResults...)
+	}
+
+	for _, afterSuite := range after.Suites {
+		if matched[suiteKey(afterSuite)] {
+			continue
+		}
+		suiteResults := compareSuites(Suite{Pkg: afterSuite.Pkg}, afterSuite, opts)
 		results = append(results, suiteResults...)
 	}
 
+	results = FilterResultsByParams(results, opts.Params)
+
+	if opts.Policy != nil {
+		results = opts.Policy.Apply(results)
+	}
+
 	return results, nil
 }
 
-func CompareTwoFiles(beforePath, afterPath string) ([]ComparisonResult, error) {
+func FilterByKind(results []ComparisonResult, kind ComparisonKind) []ComparisonResult {
+	var filtered []ComparisonResult
+	for _, r := range results {
+		if r.Kind == kind {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func suiteKey(s Suite) string {
+	return s.Pkg + "|" + s.Goos + "|" + s.Goarch
+}
+
+func CompareTwoFiles(beforePath, afterPath string, opts CompareOptions) ([]ComparisonResult, error) {
+	before, after, err := ReadRunPair(beforePath, afterPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareTwoRuns(before, after, opts)
+}
+
+func ReadRunPair(beforePath, afterPath string) (Run, Run, error) {
 	beforeRuns, err := ReadRuns(beforePath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading before file: %w", err)
+		return Run{}, Run{}, fmt.Errorf("error reading before file: %w", err)
 	}
 
 	afterRuns, err := ReadRuns(afterPath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading after file: %w", err)
+		return Run{}, Run{}, fmt.Errorf("error reading after file: %w", err)
 	}
 
 	if len(beforeRuns) == 0 {
-		return nil, fmt.Errorf("no runs in before file")
+		return Run{}, Run{}, fmt.Errorf("no runs in before file")
 	}
 
 	if len(afterRuns) == 0 {
-		return nil, fmt.Errorf("no runs in after file")
+		return Run{}, Run{}, fmt.Errorf("no runs in after file")
+	}
+
+	return beforeRuns[0], afterRuns[0], nil
+}
+
+func ReadHistoryPair(historyPath, dir, beforeRef, afterRef string) (Run, Run, error) {
+	runs, err := ReadRuns(historyPath)
+	if err != nil {
+		return Run{}, Run{}, fmt.Errorf("error reading history file: %w", err)
+	}
+
+	before, err := FindRunForRef(runs, dir, beforeRef)
+	if err != nil {
+		return Run{}, Run{}, fmt.Errorf("before: %w", err)
+	}
+
+	after, err := FindRunForRef(runs, dir, afterRef)
+	if err != nil {
+		return Run{}, Run{}, fmt.Errorf("after: %w", err)
 	}
 
-	return CompareTwoRuns(beforeRuns[0], afterRuns[0])
+	return before, after, nil
 }
 
-func compareSuites(before, after Suite) []ComparisonResult {
+func compareSuites(before, after Suite, opts CompareOptions) []ComparisonResult {
 	results := make([]ComparisonResult, 0, len(before.Benchmarks))
+	keyOf := benchmarkKey(before.Benchmarks, after.Benchmarks, opts.MatchProcs)
+
 	afterMap := make(map[string]Benchmark, len(after.Benchmarks))
 	for _, b := range after.Benchmarks {
-		afterMap[b.Name] = b
+		afterMap[keyOf(b)] = b
 	}
 
+	seen := make(map[string]bool, len(before.Benchmarks))
 	for _, beforeBench := range before.Benchmarks {
-		afterBench, ok := afterMap[beforeBench.Name]
+		key := keyOf(beforeBench)
+		seen[key] = true
+
+		afterBench, ok := afterMap[key]
 		if !ok {
+			result := ComparisonResult{
+				Name:       fmt.Sprintf("%s/%s", before.Pkg, key),
+				Pkg:        before.Pkg,
+				Params:     beforeBench.Params,
+				Kind:       ComparisonRemoved,
+				OldStatus:  beforeBench.Status,
+				OldProcs:   beforeBench.Procs,
+				OldRuns:    beforeBench.Runs,
+				OldSamples: beforeBench.SampleCount(),
+				Metrics:    compareMetrics(&beforeBench, nil, opts.Alpha),
+			}
+			result.fillCoreMetrics()
+			results = append(results, result)
 			continue
 		}
 
 		result := ComparisonResult{
-			Name:       fmt.Sprintf("%s/%s", before.Pkg, beforeBench.Name),
+			Name:       fmt.Sprintf("%s/%s", before.Pkg, key),
+			Pkg:        before.Pkg,
+			Params:     afterBench.Params,
+			OldStatus:  beforeBench.Status,
+			NewStatus:  afterBench.Status,
+			Failure:    afterBench.Failure,
+			OldProcs:   beforeBench.Procs,
+			NewProcs:   afterBench.Procs,
 			OldRuns:    beforeBench.Runs,
 			NewRuns:    afterBench.Runs,
-			OldNsPerOp: beforeBench.NsPerOp,
-			NewNsPerOp: afterBench.NsPerOp,
-		}
-
-		if beforeBench.NsPerOp > 0 {
-			result.NsPerOpDiff = afterBench.NsPerOp - beforeBench.NsPerOp
-			result.NsPerOpPct = (result.NsPerOpDiff / beforeBench.NsPerOp) * 100
+			OldSamples: beforeBench.SampleCount(),
+			NewSamples: afterBench.SampleCount(),
+			Alpha:      opts.Alpha,
+			Metrics:    compareMetrics(&beforeBench, &afterBench, opts.Alpha),
 		}
+		result.fillCoreMetrics()
 
-		if beforeBench.Mem != nil && afterBench.Mem != nil {
-			result.OldBytes = beforeBench.Mem.BytesPerOp
-			result.NewBytes = afterBench.Mem.BytesPerOp
+		results = append(results, result)
+	}
 
-			if beforeBench.Mem.BytesPerOp > 0 {
-				result.BytesDiff = afterBench.Mem.BytesPerOp - beforeBench.Mem.BytesPerOp
-				result.BytesPct = (result.BytesDiff / beforeBench.Mem.BytesPerOp) * 100
-			}
+	for _, afterBench := range after.Benchmarks {
+		key := keyOf(afterBench)
+		if seen[key] {
+			continue
+		}
+		result := ComparisonResult{
+			Name:       fmt.Sprintf("%s/%s", after.Pkg, key),
+			Pkg:        after.Pkg,
+			Params:     afterBench.Params,
+			Kind:       ComparisonAdded,
+			NewStatus:  afterBench.Status,
+			Failure:    afterBench.Failure,
+			NewProcs:   afterBench.Procs,
+			NewRuns:    afterBench.Runs,
+			NewSamples: afterBench.SampleCount(),
+			Metrics:    compareMetrics(nil, &afterBench, opts.Alpha),
+		}
+		result.fillCoreMetrics()
+		results = append(results, result)
+	}
 
-			result.OldAllocs = beforeBench.Mem.AllocsPerOp
-			result.NewAllocs = afterBench.Mem.AllocsPerOp
+	return results
+}
 
-			if beforeBench.Mem.AllocsPerOp > 0 {
-				result.AllocsDiff = afterBench.Mem.AllocsPerOp - beforeBench.Mem.AllocsPerOp
-				result.AllocsPct = (result.AllocsDiff / beforeBench.Mem.AllocsPerOp) * 100
+func benchmarkKey(before, after []Benchmark, matchProcs bool) func(Benchmark) string {
+	perProcs := make(map[string]bool)
+	for _, list := range [][]Benchmark{before, after} {
+		counts := make(map[string]int, len(list))
+		for _, b := range list {
+			counts[b.Name]++
+			if counts[b.Name] > 1 {
+				perProcs[b.Name] = true
 			}
 		}
-
-		results = append(results, result)
 	}
 
-	return results
+	return func(b Benchmark) string {
+		if matchProcs || perProcs[b.Name] {
+			return b.FullName()
+		}
+		return b.Name
+	}
 }
 
 func FormatComparisonResults(results []ComparisonResult, threshold float64) string {
 	var sb strings.Builder
 
 	sb.WriteString("Benchmark Comparison Results:\n")
-	sb.WriteString(strings.Repeat("=", 120) + "\n\n")
+	sb.WriteString(strings.Repeat("=", 137) + "\n\n")
 
 	if len(results) == 0 {
 		sb.WriteString("No benchmarks to compare.\n")
 		return sb.String()
 	}
 
-	sb.WriteString(fmt.Sprintf("%-50s %12s %12s %10s | %10s %10s %10s\n",
-		"Benchmark", "Time Old", "Time New", "Time Δ%", "Mem Old", "Mem New", "Mem Δ%"))
-	sb.WriteString(strings.Repeat("-", 120) + "\n")
+	compared := FilterByKind(results, ComparisonMatched)
+	added := FilterByKind(results, ComparisonAdded)
+	removed := FilterByKind(results, ComparisonRemoved)
+	withRules := hasRules(results)
 
-	regressions := 0
-	improvements := 0
+	sb.WriteString(fmt.Sprintf("%-50s %16s %16s %10s %8s | %10s %10s %10s",
+		"Benchmark", "Time Old", "Time New", "Time Δ%", "p", "Mem Old", "Mem New", "Mem Δ%"))
+	if withRules {
+		sb.WriteString("  Rule")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", 137) + "\n")
 
-	for _, r := range results {
+	summary := SummarizeComparison(results, threshold)
+	regressions := len(summary.Regressions)
+	improvements := len(summary.Improvements)
 
-		timeDelta := formatDelta(r.NsPerOpDiff, r.NsPerOpPct)
-		sb.WriteString(fmt.Sprintf("%-50s %12.0f %12.0f %10s | ",
+	for _, r := range compared {
+
+		timeDelta := formatSignificantDelta(r.NsPerOpPct, r.NsPerOpSig)
+		newTime := formatWithVariation(r.NewNsPerOp, r.NewNsPerOpCV, r.NewSamples)
+		if r.NewStatus != "" && r.NewStatus != StatusOK {
+			newTime = strings.ToUpper(r.NewStatus)
+			timeDelta = "-"
+		}
+		sb.WriteString(fmt.Sprintf("%-50s %16s %16s %10s %8s | ",
 			truncateString(r.Name, 50),
-			r.OldNsPerOp,
-			r.NewNsPerOp,
-			timeDelta))
+			formatWithVariation(r.OldNsPerOp, r.OldNsPerOpCV, r.OldSamples),
+			newTime,
+			timeDelta,
+			formatPValue(r.NsPerOpSig)))
 
 		if r.OldBytes > 0 || r.NewBytes > 0 {
-			memDelta := formatDelta(r.BytesDiff, r.BytesPct)
-			sb.WriteString(fmt.Sprintf("%10.0f %10.0f %10s\n",
+			memDelta := formatSignificantDelta(r.BytesPct, r.BytesSig)
+			sb.WriteString(fmt.Sprintf("%10.0f %10.0f %10s",
 				r.OldBytes,
 				r.NewBytes,
 				memDelta))
 		} else {
-			sb.WriteString(fmt.Sprintf("%10s %10s %10s\n", "-", "-", "-"))
+			sb.WriteString(fmt.Sprintf("%10s %10s %10s", "-", "-", "-"))
 		}
 
-		if r.IsRegression(threshold) {
-			regressions++
-		} else if r.NsPerOpPct < -threshold || r.BytesPct < -threshold {
-			improvements++
+		if withRules {
+			sb.WriteString(fmt.Sprintf("  [%s]", r.Rule))
+		}
+		sb.WriteString("\n")
+
+		for _, m := range r.ExtraMetrics() {
+			sb.WriteString(fmt.Sprintf("  %-48s %16s %16s %10s %8s\n",
+				truncateString(formatMetricLabel(m), 48),
+				formatMetricValue(m.Old, m.OldCV, r.OldSamples, m.HasOld),
+				formatMetricValue(m.New, m.NewCV, r.NewSamples, m.HasNew),
+				formatSignificantDelta(m.Pct, m.Sig),
+				formatPValue(m.Sig)))
 		}
 	}
 
-	sb.WriteString(strings.Repeat("-", 120) + "\n")
-	sb.WriteString(fmt.Sprintf("\nSummary: %d benchmarks compared", len(results)))
+	sb.WriteString(strings.Repeat("-", 137) + "\n")
+
+	var violations []string
+	for _, r := range results {
+		for _, v := range r.BudgetViolations {
+			violations = append(violations, fmt.Sprintf("  ! %-50s %s [%s]\n", truncateString(r.Name, 50), v, r.Rule))
+		}
+	}
+	if len(violations) > 0 {
+		sb.WriteString(fmt.Sprintf("\nBudget violations (%d):\n", len(violations)))
+		sb.WriteString(strings.Join(violations, ""))
+	}
+
+	var failing []ComparisonResult
+	for _, r := range compared {
+		if r.NewlyFailing() {
+			failing = append(failing, r)
+		}
+	}
+	if len(failing) > 0 {
+		sb.WriteString(fmt.Sprintf("\nNewly failing benchmarks (%d):\n", len(failing)))
+		for _, r := range failing {
+			sb.WriteString(fmt.Sprintf("  ✗ %-50s %s\n", truncateString(r.Name, 50), r.NewStatus))
+			for _, line := range r.Failure {
+				sb.WriteString(fmt.Sprintf("      %s\n", line))
+			}
+		}
+	}
+
+	if len(added) > 0 {
+		sb.WriteString(fmt.Sprintf("\nAdded benchmarks (%d):\n", len(added)))
+		for _, r := range added {
+			sb.WriteString(fmt.Sprintf("  + %-50s %16s\n", truncateString(r.Name, 50),
+				formatWithVariation(r.NewNsPerOp, r.NewNsPerOpCV, r.NewSamples)))
+		}
+	}
+
+	if len(removed) > 0 {
+		sb.WriteString(fmt.Sprintf("\nRemoved benchmarks (%d):\n", len(removed)))
+		for _, r := range removed {
+			sb.WriteString(fmt.Sprintf("  - %-50s %16s\n", truncateString(r.Name, 50),
+				formatWithVariation(r.OldNsPerOp, r.OldNsPerOpCV, r.OldSamples)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\nSummary: %d benchmarks compared", len(compared)))
+	if len(added) > 0 {
+		sb.WriteString(fmt.Sprintf(", %d added", len(added)))
+	}
+	if len(removed) > 0 {
+		sb.WriteString(fmt.Sprintf(", %d removed", len(removed)))
+	}
 
 	if regressions > 0 {
-		sb.WriteString(fmt.Sprintf(", %d REGRESSIONS detected (threshold: %.1f%%)", regressions, threshold))
+		sb.WriteString(fmt.Sprintf(", %d REGRESSIONS detected (default threshold: %.1f%%)", regressions, threshold))
 	}
 	if improvements > 0 {
 		sb.WriteString(fmt.Sprintf(", %d improvements", improvements))
 	}
 	sb.WriteString("\n")
+	if alpha := resultsAlpha(results); alpha > 0 {
+		sb.WriteString(fmt.Sprintf("~ marks changes that are not statistically significant (Mann-Whitney U, alpha=%.2f)\n", alpha))
+	}
 
 	return sb.String()
 }
@@ -175,6 +370,62 @@ func formatDelta(_, pct float64) string {
 	return fmt.Sprintf("%s%.1f%%", sign, pct)
 }
 
+func formatSignificantDelta(pct float64, sig Significance) string {
+	if sig.Tested && !sig.Significant {
+		return "~"
+	}
+	return formatDelta(0, pct)
+}
+
+func formatPValue(sig Significance) string {
+	if !sig.Tested {
+		return "-"
+	}
+	return fmt.Sprintf("p=%.3f", sig.PValue)
+}
+
+func hasRules(results []ComparisonResult) bool {
+	for _, r := range results {
+		if r.Rule != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func resultsAlpha(results []ComparisonResult) float64 {
+	for _, r := range results {
+		if r.NsPerOpSig.Tested || r.BytesSig.Tested || r.AllocsSig.Tested {
+			return r.Alpha
+		}
+	}
+	return 0
+}
+
+func formatMetricLabel(m MetricComparison) string {
+	if m.HigherIsBetter {
+		return "└ " + m.Unit + " (higher is better)"
+	}
+	return "└ " + m.Unit
+}
+
+func formatMetricValue(value, cv float64, samples int, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	if samples <= 1 {
+		return fmt.Sprintf("%.4g", value)
+	}
+	return fmt.Sprintf("%.4g ±%.0f%%", value, cv)
+}
+
+func formatWithVariation(value, cv float64, samples int) string {
+	if samples <= 1 {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.0f ±%.0f%%", value, cv)
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -183,38 +434,154 @@ func truncateString(s string, maxLen int) string {
 }
 
 type ComparisonJSON struct {
-	Name              string  `json:"name"`
-	OldNsPerOp        float64 `json:"oldNsPerOp"`
-	NewNsPerOp        float64 `json:"newNsPerOp"`
-	NsPerOpChange     float64 `json:"nsPerOpChange"`
-	OldBytesPerOp     float64 `json:"oldBytesPerOp"`
-	NewBytesPerOp     float64 `json:"newBytesPerOp"`
-	BytesPerOpChange  float64 `json:"bytesPerOpChange"`
-	OldAllocsPerOp    float64 `json:"oldAllocsPerOp,omitempty"`
-	NewAllocsPerOp    float64 `json:"newAllocsPerOp,omitempty"`
-	AllocsPerOpChange float64 `json:"allocsPerOpChange,omitempty"`
+	Name              string            `json:"name"`
+	Params            map[string]string `json:"params,omitempty"`
+	OldStatus         string            `json:"oldStatus,omitempty"`
+	NewStatus         string            `json:"newStatus,omitempty"`
+	Failure           []string          `json:"failure,omitempty"`
+	OldSamples        int               `json:"oldSamples"`
+	NewSamples        int               `json:"newSamples"`
+	OldNsPerOp        float64           `json:"oldNsPerOp"`
+	NewNsPerOp        float64           `json:"newNsPerOp"`
+	NsPerOpChange     float64           `json:"nsPerOpChange"`
+	OldNsPerOpCV      float64           `json:"oldNsPerOpCV,omitempty"`
+	NewNsPerOpCV      float64           `json:"newNsPerOpCV,omitempty"`
+	OldBytesPerOp     float64           `json:"oldBytesPerOp"`
+	NewBytesPerOp     float64           `json:"newBytesPerOp"`
+	BytesPerOpChange  float64           `json:"bytesPerOpChange"`
+	OldAllocsPerOp    float64           `json:"oldAllocsPerOp,omitempty"`
+	NewAllocsPerOp    float64           `json:"newAllocsPerOp,omitempty"`
+	AllocsPerOpChange float64           `json:"allocsPerOpChange,omitempty"`
+	NsPerOpSig        *Significance     `json:"nsPerOpSignificance,omitempty"`
+	BytesPerOpSig     *Significance     `json:"bytesPerOpSignificance,omitempty"`
+	AllocsPerOpSig    *Significance     `json:"allocsPerOpSignificance,omitempty"`
+	Metrics           []MetricJSON      `json:"metrics,omitempty"`
+	Rule              string            `json:"rule,omitempty"`
+	BudgetViolations  []string          `json:"budgetViolations,omitempty"`
+}
+
+type MetricJSON struct {
+	Unit           string        `json:"unit"`
+	Old            *float64      `json:"old,omitempty"`
+	New            *float64      `json:"new,omitempty"`
+	Change         float64       `json:"change"`
+	HigherIsBetter bool          `json:"higherIsBetter,omitempty"`
+	Significance   *Significance `json:"significance,omitempty"`
+}
+
+type ComparisonReportJSON struct {
+	Compared []ComparisonJSON `json:"compared"`
+	Added    []ComparisonJSON `json:"added"`
+	Removed  []ComparisonJSON `json:"removed"`
 }
 
 func FormatComparisonAsJSON(results []ComparisonResult) string {
+	report := ComparisonReportJSON{
+		Compared: toComparisonJSON(FilterByKind(results, ComparisonMatched)),
+		Added:    toComparisonJSON(FilterByKind(results, ComparisonAdded)),
+		Removed:  toComparisonJSON(FilterByKind(results, ComparisonRemoved)),
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+func toComparisonJSON(results []ComparisonResult) []ComparisonJSON {
 	jsonResults := make([]ComparisonJSON, len(results))
 	for i, r := range results {
 		jsonResults[i] = ComparisonJSON{
 			Name:              r.Name,
+			Params:            r.Params,
+			OldStatus:         r.OldStatus,
+			NewStatus:         r.NewStatus,
+			Failure:           r.Failure,
+			OldSamples:        r.OldSamples,
+			NewSamples:        r.NewSamples,
 			OldNsPerOp:        r.OldNsPerOp,
 			NewNsPerOp:        r.NewNsPerOp,
 			NsPerOpChange:     r.NsPerOpPct,
+			OldNsPerOpCV:      r.OldNsPerOpCV,
+			NewNsPerOpCV:      r.NewNsPerOpCV,
 			OldBytesPerOp:     r.OldBytes,
 			NewBytesPerOp:     r.NewBytes,
 			BytesPerOpChange:  r.BytesPct,
 			OldAllocsPerOp:    r.OldAllocs,
 			NewAllocsPerOp:    r.NewAllocs,
 			AllocsPerOpChange: r.AllocsPct,
+			NsPerOpSig:        significanceJSON(r.NsPerOpSig),
+			BytesPerOpSig:     significanceJSON(r.BytesSig),
+			AllocsPerOpSig:    significanceJSON(r.AllocsSig),
+			Metrics:           toMetricJSON(r.Metrics),
+			Rule:              r.Rule,
+			BudgetViolations:  r.BudgetViolations,
 		}
 	}
+	return jsonResults
+}
 
-	data, err := json.MarshalIndent(jsonResults, "", "  ")
-	if err != nil {
-		return "[]"
+func toMetricJSON(metrics []MetricComparison) []MetricJSON {
+	jsonMetrics := make([]MetricJSON, len(metrics))
+	for i, m := range metrics {
+		jsonMetrics[i] = MetricJSON{
+			Unit:           m.Unit,
+			Change:         m.Pct,
+			HigherIsBetter: m.HigherIsBetter,
+			Significance:   significanceJSON(m.Sig),
+		}
+		if m.HasOld {
+			jsonMetrics[i].Old = &m.Old
+		}
+		if m.HasNew {
+			jsonMetrics[i].New = &m.New
+		}
 	}
-	return string(data)
+	return jsonMetrics
+}
+
+func significanceJSON(sig Significance) *Significance {
+	if !sig.Tested {
+		return nil
+	}
+	return &sig
+}
+
+type ComparisonSummary struct {
+	Compared     int      `json:"compared"`
+	Regressions  []string `json:"regressions"`
+	Improvements []string `json:"improvements"`
+	Added        []string `json:"added"`
+	Removed      []string `json:"removed"`
+}
+
+func SummarizeComparison(results []ComparisonResult, threshold float64) ComparisonSummary {
+	summary := ComparisonSummary{
+		Regressions:  []string{},
+		Improvements: []string{},
+		Added:        []string{},
+		Removed:      []string{},
+	}
+
+	for _, r := range results {
+		switch r.Kind {
+		case ComparisonAdded:
+			summary.Added = append(summary.Added, r.Name)
+			if len(r.BudgetViolations) > 0 {
+				summary.Regressions = append(summary.Regressions, r.Name)
+			}
+		case ComparisonRemoved:
+			summary.Removed = append(summary.Removed, r.Name)
+		default:
+			summary.Compared++
+			if r.IsRegression(threshold) {
+				summary.Regressions = append(summary.Regressions, r.Name)
+			} else if r.IsImprovement(threshold) {
+				summary.Improvements = append(summary.Improvements, r.Name)
+			}
+		}
+	}
+
+	return summary
 }
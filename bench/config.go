@@ -0,0 +1,72 @@
+package bench
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+func (r *Run) CPUs() []string {
+	var cpus []string
+	for _, s := range r.Suites {
+		if s.CPU != "" && !slices.Contains(cpus, s.CPU) {
+			cpus = append(cpus, s.CPU)
+		}
+	}
+	return cpus
+}
+
+func (r *Run) Config() map[string][]string {
+	config := make(map[string][]string)
+	for _, s := range r.Suites {
+		for k, v := range s.Config {
+			if !slices.Contains(config[k], v) {
+				config[k] = append(config[k], v)
+			}
+		}
+	}
+	return config
+}
+
+func ConfigKeys(config map[string][]string) []string {
+	return slices.Sorted(maps.Keys(config))
+}
+
+func EnvironmentWarnings(before, after Run) []string {
+	var warnings []string
+
+	oldCPUs, newCPUs := before.CPUs(), after.CPUs()
+	cpuChanged := len(oldCPUs) > 0 && len(newCPUs) > 0 && !sameSet(oldCPUs, newCPUs)
+	if cpuChanged {
+		warnings = append(warnings, "runs used different CPUs: before "+
+			strings.Join(oldCPUs, ", ")+"; after "+strings.Join(newCPUs, ", "))
+	}
+
+	var hardware []string
+	for _, c := range DiffEnvironment(before.Environment, after.Environment) {
+		if !c.Hardware || (c.Field == "cpu" && cpuChanged) {
+			continue
+		}
+		hardware = append(hardware, c.Field+" "+valueOrDash(c.Old)+" -> "+valueOrDash(c.New))
+	}
+	if len(hardware) > 0 {
+		warnings = append(warnings, "runs used different hardware: "+strings.Join(hardware, ", "))
+	}
+
+	warnings = append(warnings, StabilityWarnings("before", before)...)
+	warnings = append(warnings, StabilityWarnings("after", after)...)
+
+	return warnings
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package bench
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Diagnostic struct {
+	Line   int    `json:"line"`
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+}
+
+func (d Diagnostic) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", d.Line, d.Reason, d.Text)
+}
+
+type diagnostics struct {
+	strict  bool
+	lineNo  int
+	lineMap []int
+	list    []Diagnostic
+}
+
+func (d *diagnostics) SetStrict(strict bool) {
+	d.strict = strict
+}
+
+func (d *diagnostics) Diagnostics() []Diagnostic {
+	return d.list
+}
+
+func (d *diagnostics) line() int {
+	if d.lineNo > 0 && d.lineNo <= len(d.lineMap) {
+		return d.lineMap[d.lineNo-1]
+	}
+	return d.lineNo
+}
+
+func (d *diagnostics) report(text, reason string) error {
+	diag := Diagnostic{Line: d.line(), Text: text, Reason: reason}
+	if d.strict {
+		return diag
+	}
+	d.list = append(d.list, diag)
+	return nil
+}
+
+func (d *diagnostics) parseBenchmarkLine(line string) (*Benchmark, error) {
+	parts := strings.Split(line, "\t")
+	if len(parts) == 1 {
+		return nil, d.report(line, "not a benchmark result line")
+	}
+	if len(parts) < 3 {
+		return nil, d.report(line, fmt.Sprintf("invalid benchmark format: expected at least 3 fields, got %d", len(parts)))
+	}
+
+	name, procs := SplitProcs(strings.TrimSpace(parts[0]))
+	path, params := ParsePath(name)
+	bench := &Benchmark{
+		Name:   name,
+		Procs:  procs,
+		Path:   path,
+		Params: params,
+		Status: StatusOK,
+	}
+
+	runs, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	if err != nil {
+		return nil, d.report(line, fmt.Sprintf("%s: could not parse runs", name))
+	}
+	bench.Runs = runs
+
+	for _, part := range parts[2:] {
+		metric := strings.TrimSpace(part)
+		if metric == "" {
+			continue
+		}
+
+		valueStr, unit, found := strings.Cut(metric, " ")
+		if !found {
+			if err := d.report(line, fmt.Sprintf("%s: invalid metric format %q", name, metric)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			if err := d.report(line, fmt.Sprintf("%s: could not parse value %q", name, valueStr)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		bench.setMetric(strings.TrimSpace(unit), value)
+	}
+
+	return bench, nil
+}
+
+func bareBenchmarkName(line string) (string, bool) {
+	name := strings.TrimSpace(line)
+	if strings.ContainsAny(name, " \t") {
+		return "", false
+	}
+	return name, true
+}
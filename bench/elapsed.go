@@ -0,0 +1,63 @@
+package bench
+
+import (
+	"slices"
+	"time"
+)
+
+type ElapsedSeries struct {
+	Pkg    string
+	Path   string
+	Values []float64
+}
+
+func (r *Run) Elapsed() float64 {
+	var total float64
+	for _, s := range r.Suites {
+		total += s.Elapsed
+	}
+	return total
+}
+
+func ElapsedHistory(runs []Run) []ElapsedSeries {
+	var series []ElapsedSeries
+	for i, run := range runs {
+		for _, s := range run.Suites {
+			if s.Elapsed <= 0 {
+				continue
+			}
+
+			j := slices.IndexFunc(series, func(e ElapsedSeries) bool { return e.Pkg == s.Pkg })
+			if j < 0 {
+				series = append(series, ElapsedSeries{Pkg: s.Pkg, Path: s.DisplayPath(), Values: make([]float64, len(runs))})
+				j = len(series) - 1
+			}
+			series[j].Values[i] += s.Elapsed
+		}
+	}
+	return series
+}
+
+func (e ElapsedSeries) Change() (float64, bool) {
+	first, last := -1, -1
+	for i, v := range e.Values {
+		if v <= 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	if first < 0 || first == last {
+		return 0, false
+	}
+	return (e.Values[last] - e.Values[first]) / e.Values[first] * 100, true
+}
+
+func FormatElapsed(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
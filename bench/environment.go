@@ -0,0 +1,191 @@
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+type Environment struct {
+	Hostname   string    `json:"hostname,omitempty"`
+	Kernel     string    `json:"kernel,omitempty"`
+	CPU        string    `json:"cpu,omitempty"`
+	Cores      int       `json:"cores,omitempty"`
+	Memory     uint64    `json:"memory,omitempty"`
+	GOMAXPROCS int       `json:"gomaxprocs,omitempty"`
+	Governor   string    `json:"governor,omitempty"`
+	LoadAvg    []float64 `json:"loadAvg,omitempty"`
+}
+
+type EnvironmentChange struct {
+	Field    string
+	Old      string
+	New      string
+	Hardware bool
+}
+
+func CaptureEnvironment() *Environment {
+	env := &Environment{
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Governor:   readFirstLine("/sys/devices/system/cpu/cpu0/cpufreq/scaling_governor"),
+	}
+
+	env.Hostname, _ = os.Hostname()
+
+	if release := readFirstLine("/proc/sys/kernel/osrelease"); release != "" {
+		env.Kernel = strings.TrimSpace(readFirstLine("/proc/sys/kernel/ostype") + " " + release)
+	}
+
+	env.CPU, env.Cores = readCPUInfo("/proc/cpuinfo")
+	if env.Cores == 0 {
+		env.Cores = runtime.NumCPU()
+	}
+
+	if kb, ok := readMemInfo("/proc/meminfo", "MemTotal"); ok {
+		env.Memory = kb * 1024
+	}
+
+	for _, field := range strings.Fields(readFirstLine("/proc/loadavg")) {
+		if len(env.LoadAvg) == 3 {
+			break
+		}
+		load, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			break
+		}
+		env.LoadAvg = append(env.LoadAvg, load)
+	}
+
+	return env
+}
+
+func DiffEnvironment(before, after *Environment) []EnvironmentChange {
+	if before == nil || after == nil {
+		return nil
+	}
+
+	var changes []EnvironmentChange
+	add := func(field, old, new string, hardware bool) {
+		if old != new {
+			changes = append(changes, EnvironmentChange{Field: field, Old: old, New: new, Hardware: hardware})
+		}
+	}
+
+	add("hostname", before.Hostname, after.Hostname, false)
+	add("kernel", before.Kernel, after.Kernel, false)
+	add("cpu", before.CPU, after.CPU, true)
+	add("cores", formatCount(before.Cores), formatCount(after.Cores), true)
+	add("memory", FormatMemory(before.Memory), FormatMemory(after.Memory), true)
+	add("gomaxprocs", formatCount(before.GOMAXPROCS), formatCount(after.GOMAXPROCS), false)
+	add("governor", before.Governor, after.Governor, false)
+	add("load", FormatLoadAvg(before.LoadAvg), FormatLoadAvg(after.LoadAvg), false)
+
+	return changes
+}
+
+func FormatEnvironmentDiff(changes []EnvironmentChange) string {
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Environment changes:\n")
+	sb.WriteString(fmt.Sprintf("%-12s %-36s %s\n", "Field", "Before", "After"))
+	sb.WriteString(strings.Repeat("-", 86) + "\n")
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("%-12s %-36s %s\n", c.Field,
+			truncateString(valueOrDash(c.Old), 36), truncateString(valueOrDash(c.New), 36)))
+	}
+	return sb.String()
+}
+
+func FormatMemory(bytes uint64) string {
+	if bytes == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f GiB", float64(bytes)/(1<<30))
+}
+
+func FormatLoadAvg(load []float64) string {
+	parts := make([]string, 0, len(load))
+	for _, l := range load {
+		parts = append(parts, strconv.FormatFloat(l, 'f', 2, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatCount(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func readFirstLine(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSpace(line)
+}
+
+func readCPUInfo(path string) (string, int) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0
+	}
+	defer f.Close()
+
+	var model string
+	cores := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "processor":
+			cores++
+		case "model name", "Model", "cpu model":
+			if model == "" {
+				model = strings.TrimSpace(value)
+			}
+		}
+	}
+	return model, cores
+}
+
+func readMemInfo(path, key string) (uint64, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		k, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || k != key {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseUint(fields[0], 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
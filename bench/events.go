@@ -0,0 +1,99 @@
+package bench
+
+type EventKind int
+
+const (
+	EventSuiteStart EventKind = iota
+	EventConfig
+	EventBenchmark
+	EventLog
+	EventFailure
+	EventSuiteEnd
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventSuiteStart:
+		return "suite-start"
+	case EventConfig:
+		return "config"
+	case EventBenchmark:
+		return "benchmark"
+	case EventLog:
+		return "log"
+	case EventFailure:
+		return "failure"
+	case EventSuiteEnd:
+		return "suite-end"
+	}
+	return "unknown"
+}
+
+type Event struct {
+	Kind      EventKind
+	Line      int
+	Suite     *Suite
+	Key       string
+	Value     string
+	Benchmark *Benchmark
+	Name      string
+	Procs     int
+	Text      string
+	Status    string
+	Elapsed   float64
+}
+
+type Collector struct {
+	suites     []Suite
+	modulePath string
+}
+
+func NewCollector() *Collector {
+	return &Collector{suites: make([]Suite, 0, 4)}
+}
+
+func (c *Collector) SetModulePath(module string) {
+	c.modulePath = module
+}
+
+func (c *Collector) Handle(ev Event) {
+	if ev.Kind == EventSuiteStart {
+		c.suites = append(c.suites, *ev.Suite)
+		return
+	}
+	if len(c.suites) == 0 {
+		return
+	}
+
+	suite := &c.suites[len(c.suites)-1]
+	switch ev.Kind {
+	case EventConfig:
+		suite.SetConfig(ev.Key, ev.Value)
+		if ev.Key == "pkg" {
+			suite.SetShortPath(c.modulePath)
+		}
+	case EventBenchmark:
+		suite.AddBenchmark(*ev.Benchmark)
+	case EventLog:
+		if i := suite.indexOf(ev.Name, ev.Procs); i >= 0 {
+			suite.Benchmarks[i].Log = append(suite.Benchmarks[i].Log, ev.Text)
+		}
+	case EventFailure:
+		if ev.Name == "" {
+			suite.Failure = append(suite.Failure, ev.Text)
+		} else if i := suite.indexOf(ev.Name, ev.Procs); i >= 0 {
+			suite.Benchmarks[i].Failure = append(suite.Benchmarks[i].Failure, ev.Text)
+		}
+	case EventSuiteEnd:
+		if ev.Status != "" {
+			suite.Status = ev.Status
+		}
+		if ev.Elapsed > 0 {
+			suite.Elapsed = ev.Elapsed
+		}
+	}
+}
+
+func (c *Collector) Suites() []Suite {
+	return c.suites
+}
@@ -0,0 +1,129 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type GitInfo struct {
+	Commit  string `json:"commit"`
+	Branch  string `json:"branch,omitempty"`
+	Dirty   bool   `json:"dirty,omitempty"`
+	Time    int64  `json:"time,omitempty"`
+	Subject string `json:"subject,omitempty"`
+}
+
+func (g *GitInfo) ShortCommit() string {
+	if len(g.Commit) > 12 {
+		return g.Commit[:12]
+	}
+	return g.Commit
+}
+
+func CaptureGit(dir string) (*GitInfo, error) {
+	if out, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err != nil || out != "true" {
+		return nil, nil
+	}
+
+	commit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, nil
+	}
+	info := &GitInfo{Commit: commit}
+
+	if branch, err := runGit(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		info.Branch = branch
+	}
+
+	status, err := runGit(dir, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	info.Dirty = status != ""
+
+	log, err := runGit(dir, "log", "-1", "--format=%ct%n%s", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	ts, subject, _ := strings.Cut(log, "\n")
+	info.Time, _ = strconv.ParseInt(ts, 10, 64)
+	info.Subject = subject
+
+	return info, nil
+}
+
+func ResolveGitRef(dir, ref string) (string, error) {
+	commit, err := runGit(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown git ref %q", ref)
+	}
+	return commit, nil
+}
+
+func FindRunForCommit(runs []Run, commit string) (Run, bool) {
+	var found Run
+	ok := false
+	for _, run := range runs {
+		if !runMatchesCommit(run, commit) {
+			continue
+		}
+		if !ok || run.Date >= found.Date {
+			found = run
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+func FindRunForRef(runs []Run, dir, ref string) (Run, error) {
+	commit, err := ResolveGitRef(dir, ref)
+	if err != nil {
+		return Run{}, err
+	}
+
+	run, ok := FindRunForCommit(runs, commit)
+	if !ok {
+		return Run{}, fmt.Errorf("no run recorded for %s (%s)", ref, commit[:min(12, len(commit))])
+	}
+	return run, nil
+}
+
+func GitTopLevel(dir string) (string, error) {
+	return runGit(dir, "rev-parse", "--show-toplevel")
+}
+
+func AddWorktree(dir, path, commit string) error {
+	_, err := runGit(dir, "worktree", "add", "--detach", path, commit)
+	return err
+}
+
+func RemoveWorktree(dir, path string) error {
+	_, err := runGit(dir, "worktree", "remove", "--force", path)
+	return err
+}
+
+func runMatchesCommit(run Run, commit string) bool {
+	if run.Git != nil && run.Git.Commit == commit {
+		return true
+	}
+	return len(run.Version) >= 7 && strings.HasPrefix(commit, run.Version)
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -14,6 +14,23 @@ func MergeRuns(runs ...[]Run) []Run {
 	return result
 }
 
+func MergeSuites(dst, src []Suite) []Suite {
+	for _, s := range src {
+		i := slices.IndexFunc(dst, func(d Suite) bool { return suiteKey(d) == suiteKey(s) })
+		if i < 0 {
+			dst = append(dst, s)
+			continue
+		}
+
+		for _, b := range s.Benchmarks {
+			dst[i].AddBenchmark(b)
+		}
+		dst[i].Status = worseStatus(dst[i].Status, s.Status)
+		dst[i].Failure = append(dst[i].Failure, s.Failure...)
+	}
+	return dst
+}
+
 func MergeRunsFromFiles(paths ...string) ([]Run, error) {
 	var allRuns []Run
 
@@ -0,0 +1,153 @@
+package bench
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type MetricDirection int
+
+const (
+	LowerIsBetter MetricDirection = iota
+	HigherIsBetter
+)
+
+var (
+	directionsMu     sync.RWMutex
+	metricDirections = map[string]MetricDirection{
+		UnitNsPerOp:     LowerIsBetter,
+		UnitBytesPerOp:  LowerIsBetter,
+		UnitAllocsPerOp: LowerIsBetter,
+		UnitMBPerSec:    HigherIsBetter,
+	}
+)
+
+var coreUnits = []string{UnitNsPerOp, UnitBytesPerOp, UnitAllocsPerOp, UnitMBPerSec}
+
+func RegisterMetricDirection(unit string, direction MetricDirection) {
+	directionsMu.Lock()
+	defer directionsMu.Unlock()
+	metricDirections[unit] = direction
+}
+
+func DirectionOf(unit string) MetricDirection {
+	directionsMu.RLock()
+	d, ok := metricDirections[unit]
+	directionsMu.RUnlock()
+	if ok {
+		return d
+	}
+
+	if strings.HasSuffix(unit, "/s") {
+		return HigherIsBetter
+	}
+	return LowerIsBetter
+}
+
+func IsCoreMetric(unit string) bool {
+	return unit == UnitNsPerOp || unit == UnitBytesPerOp || unit == UnitAllocsPerOp
+}
+
+type MetricComparison struct {
+	Unit           string
+	Old            float64
+	New            float64
+	Diff           float64
+	Pct            float64
+	OldCV          float64
+	NewCV          float64
+	HigherIsBetter bool
+	HasOld         bool
+	HasNew         bool
+	Sig            Significance
+}
+
+func (m MetricComparison) Worsening() float64 {
+	if m.HigherIsBetter {
+		return -m.Pct
+	}
+	return m.Pct
+}
+
+func (m MetricComparison) IsRegression(threshold float64) bool {
+	return m.HasOld && m.HasNew && m.Worsening() > threshold && m.Sig.Confirmed()
+}
+
+func (m MetricComparison) IsImprovement(threshold float64) bool {
+	return m.HasOld && m.HasNew && m.Worsening() < -threshold && m.Sig.Confirmed()
+}
+
+func compareMetrics(before, after *Benchmark, alpha float64) []MetricComparison {
+	var oldMetrics, newMetrics map[string]float64
+	if before != nil {
+		oldMetrics = before.Metrics()
+	}
+	if after != nil {
+		newMetrics = after.Metrics()
+	}
+
+	units := make([]string, 0, len(oldMetrics)+len(newMetrics))
+	for u := range oldMetrics {
+		units = append(units, u)
+	}
+	for u := range newMetrics {
+		if _, ok := oldMetrics[u]; !ok {
+			units = append(units, u)
+		}
+	}
+	sortUnits(units)
+
+	comparisons := make([]MetricComparison, 0, len(units))
+	for _, unit := range units {
+		oldValue, hasOld := oldMetrics[unit]
+		newValue, hasNew := newMetrics[unit]
+
+		mc := MetricComparison{
+			Unit:           unit,
+			Old:            oldValue,
+			New:            newValue,
+			HasOld:         hasOld,
+			HasNew:         hasNew,
+			HigherIsBetter: DirectionOf(unit) == HigherIsBetter,
+		}
+		if hasOld {
+			mc.OldCV = before.Stats[unit].CV
+		}
+		if hasNew {
+			mc.NewCV = after.Stats[unit].CV
+		}
+
+		if hasOld && hasNew {
+			if oldValue > 0 {
+				mc.Diff = newValue - oldValue
+				mc.Pct = (mc.Diff / oldValue) * 100
+			}
+			mc.Sig = SignificanceOf(before.Values(unit), after.Values(unit), alpha)
+		}
+
+		comparisons = append(comparisons, mc)
+	}
+
+	return comparisons
+}
+
+func sortUnits(units []string) {
+	slices.SortFunc(units, func(a, b string) int {
+		ia, ib := slices.Index(coreUnits, a), slices.Index(coreUnits, b)
+		if ia < 0 {
+			ia = math.MaxInt
+		}
+		if ib < 0 {
+			ib = math.MaxInt
+		}
+		if ia != ib {
+			if ia < ib {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+}
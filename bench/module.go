@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func FindModulePath(dir string) (string, error) {
+	for {
+		path := filepath.Join(dir, "go.mod")
+		f, err := os.Open(path)
+		if err == nil {
+			defer f.Close()
+			return readModulePath(f, path)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("error opening %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func readModulePath(f *os.File, path string) (string, error) {
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		rest, ok := strings.CutPrefix(line, "module")
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+
+		module := strings.TrimSpace(rest)
+		if i := strings.Index(module, "//"); i >= 0 {
+			module = strings.TrimSpace(module[:i])
+		}
+		if unquoted, err := strconv.Unquote(module); err == nil {
+			module = unquoted
+		}
+		return module, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return "", nil
+}
+
+func ShortPackagePath(pkg, module string) string {
+	if pkg == "" || module == "" {
+		return ""
+	}
+	if pkg == module {
+		return module[strings.LastIndex(module, "/")+1:]
+	}
+	if rest, ok := strings.CutPrefix(pkg, module+"/"); ok {
+		return rest
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const DefaultNotesRef = "refs/notes/zeno"
+
+func ReadNoteRuns(dir, ref, commit string) ([]Run, error) {
+	out, err := runGit(dir, "notes", "--ref", ref, "list", commit)
+	if err != nil || out == "" {
+		return nil, nil
+	}
+
+	note, err := runGit(dir, "notes", "--ref", ref, "show", commit)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := DecodeRuns(strings.NewReader(note))
+	if err != nil {
+		return nil, fmt.Errorf("note on %s: %w", commit, err)
+	}
+	return runs, nil
+}
+
+func WriteRunNote(dir, ref, commit string, run *Run) error {
+	runs, err := ReadNoteRuns(dir, ref, commit)
+	if err != nil {
+		return err
+	}
+	runs = append(runs, *run)
+
+	var buf bytes.Buffer
+	if err := EncodeRuns(&buf, runs); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("git", "notes", "--ref", ref, "add", "--force", "--file", "-", commit)
+	cmd.Dir = dir
+	cmd.Stdin = &buf
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git notes add: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func ReadNotesHistory(dir, ref, revision string, limit int) ([]Run, error) {
+	args := []string{"log", "--notes=" + ref, "--format=%H%x00%N%x1e"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", limit))
+	}
+	args = append(args, revision, "--")
+
+	out, err := runGit(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []Run
+	for _, record := range strings.Split(out, "\x1e") {
+		commit, note, ok := strings.Cut(strings.TrimSpace(record), "\x00")
+		if !ok || strings.TrimSpace(note) == "" {
+			continue
+		}
+
+		runs, err := DecodeRuns(strings.NewReader(note))
+		if err != nil {
+			return nil, fmt.Errorf("note on %s: %w", commit, err)
+		}
+		for i := range runs {
+			if runs[i].Git == nil {
+				runs[i].Git = &GitInfo{Commit: commit}
+			}
+		}
+		history = append(runs, history...)
+	}
+
+	return history, nil
+}
+
+func ReadNotesPair(dir, ref, beforeRef, afterRef string) (Run, Run, error) {
+	before, err := readNoteRunForRef(dir, ref, beforeRef)
+	if err != nil {
+		return Run{}, Run{}, fmt.Errorf("before: %w", err)
+	}
+
+	after, err := readNoteRunForRef(dir, ref, afterRef)
+	if err != nil {
+		return Run{}, Run{}, fmt.Errorf("after: %w", err)
+	}
+
+	return before, after, nil
+}
+
+func readNoteRunForRef(dir, ref, rev string) (Run, error) {
+	commit, err := ResolveGitRef(dir, rev)
+	if err != nil {
+		return Run{}, err
+	}
+
+	runs, err := ReadNoteRuns(dir, ref, commit)
+	if err != nil {
+		return Run{}, err
+	}
+	if len(runs) == 0 {
+		return Run{}, fmt.Errorf("no run recorded for %s (%s) in %s", rev, commit[:min(12, len(commit))], ref)
+	}
+	return latestRun(runs), nil
+}
+
+func latestRun(runs []Run) Run {
+	latest := runs[0]
+	for _, run := range runs[1:] {
+		if run.Date >= latest.Date {
+			latest = run
+		}
+	}
+	return latest
+}
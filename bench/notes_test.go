@@ -0,0 +1,69 @@
+package bench
+
+import (
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func gitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_AUTHOR_NAME", "zeno")
+	t.Setenv("GIT_AUTHOR_EMAIL", "zeno@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "zeno")
+	t.Setenv("GIT_COMMITTER_EMAIL", "zeno@example.com")
+	if _, err := runGit(dir, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func gitCommit(t *testing.T, dir string) string {
+	t.Helper()
+	if _, err := runGit(dir, "commit", "-q", "--allow-empty", "-m", "commit"); err != nil {
+		t.Fatal(err)
+	}
+	commit, err := ResolveGitRef(dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commit
+}
+
+func TestReadNotesHistoryOrder(t *testing.T) {
+	dir := gitRepo(t)
+
+	first := gitCommit(t, dir)
+	for _, version := range []string{"first-a", "first-b"} {
+		if err := WriteRunNote(dir, DefaultNotesRef, first, &Run{Version: version}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	second := gitCommit(t, dir)
+	if err := WriteRunNote(dir, DefaultNotesRef, second, &Run{Version: "second"}); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := ReadNotesHistory(dir, DefaultNotesRef, "HEAD", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var versions, commits []string
+	for _, run := range history {
+		versions = append(versions, run.Version)
+		commits = append(commits, run.Git.Commit)
+	}
+	if want := []string{"first-a", "first-b", "second"}; !slices.Equal(versions, want) {
+		t.Errorf("versions = %v, want %v", versions, want)
+	}
+	if want := []string{first, first, second}; !slices.Equal(commits, want) {
+		t.Errorf("commits = %v, want %v", commits, want)
+	}
+}
@@ -0,0 +1,245 @@
+package bench
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type PivotTable struct {
+	Param   string
+	Unit    string
+	Delta   bool
+	Columns []string
+	Rows    []PivotRow
+}
+
+type PivotRow struct {
+	Label  string
+	Values map[string]float64
+}
+
+func ParsePath(name string) ([]string, map[string]string) {
+	segments := strings.Split(name, "/")
+	if len(segments) == 1 {
+		return nil, nil
+	}
+
+	var params map[string]string
+	for _, seg := range segments[1:] {
+		key, value, found := strings.Cut(seg, "=")
+		if !found || key == "" {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string, len(segments)-1)
+		}
+		params[key] = value
+	}
+
+	return segments, params
+}
+
+func (b *Benchmark) Segments() []string {
+	if len(b.Path) > 0 {
+		return b.Path
+	}
+	return strings.Split(b.Name, "/")
+}
+
+func ParamKeys(benchmarks []Benchmark) []string {
+	keys := make(map[string]bool)
+	for _, b := range benchmarks {
+		for k := range b.Params {
+			keys[k] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+func ParseParamFilters(filters []string) (map[string]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[string]string, len(filters))
+	for _, f := range filters {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid parameter filter %q: expected key=value", f)
+		}
+		parsed[k] = v
+	}
+	return parsed, nil
+}
+
+func FilterResultsByParams(results []ComparisonResult, filters map[string]string) []ComparisonResult {
+	if len(filters) == 0 {
+		return results
+	}
+
+	var filtered []ComparisonResult
+	for _, r := range results {
+		if matchesParams(r.Params, filters) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func matchesParams(params, filters map[string]string) bool {
+	for k, v := range filters {
+		if value, ok := params[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func PivotBenchmarks(benchmarks []Benchmark, param, unit string) PivotTable {
+	table := PivotTable{Param: param, Unit: unit}
+	rows := make(map[string]*PivotRow)
+	var order []string
+
+	for _, b := range benchmarks {
+		column, ok := b.Params[param]
+		if !ok {
+			continue
+		}
+		value, ok := b.Metrics()[unit]
+		if !ok {
+			continue
+		}
+
+		label := pivotLabel(b.Name, param)
+		if b.Procs > 0 {
+			label += "-" + strconv.Itoa(b.Procs)
+		}
+		row, ok := rows[label]
+		if !ok {
+			row = &PivotRow{Label: label, Values: make(map[string]float64)}
+			rows[label] = row
+			order = append(order, label)
+		}
+		row.Values[column] = value
+
+		if !slices.Contains(table.Columns, column) {
+			table.Columns = append(table.Columns, column)
+		}
+	}
+
+	sortParamValues(table.Columns)
+	for _, label := range order {
+		table.Rows = append(table.Rows, *rows[label])
+	}
+
+	return table
+}
+
+func PivotResults(results []ComparisonResult, param string) PivotTable {
+	table := PivotTable{Param: param, Unit: UnitNsPerOp, Delta: true}
+	rows := make(map[string]*PivotRow)
+	var order []string
+
+	for _, r := range results {
+		column, ok := r.Params[param]
+		if !ok || r.Kind != ComparisonMatched {
+			continue
+		}
+
+		label := pivotLabel(r.Name, param)
+		row, ok := rows[label]
+		if !ok {
+			row = &PivotRow{Label: label, Values: make(map[string]float64)}
+			rows[label] = row
+			order = append(order, label)
+		}
+		row.Values[column] = r.NsPerOpPct
+
+		if !slices.Contains(table.Columns, column) {
+			table.Columns = append(table.Columns, column)
+		}
+	}
+
+	sortParamValues(table.Columns)
+	for _, label := range order {
+		table.Rows = append(table.Rows, *rows[label])
+	}
+
+	return table
+}
+
+func FormatPivotTable(t PivotTable) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("%s by %s", t.Unit, t.Param)
+	if t.Delta {
+		title = fmt.Sprintf("%s change by %s", t.Unit, t.Param)
+	}
+	sb.WriteString(title + ":\n")
+
+	if len(t.Rows) == 0 {
+		sb.WriteString(fmt.Sprintf("No benchmarks with parameter %q.\n", t.Param))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%-50s", "Benchmark"))
+	for _, c := range t.Columns {
+		sb.WriteString(fmt.Sprintf(" %14s", truncateString(t.Param+"="+c, 14)))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", 50+15*len(t.Columns)) + "\n")
+
+	for _, row := range t.Rows {
+		sb.WriteString(fmt.Sprintf("%-50s", truncateString(row.Label, 50)))
+		for _, c := range t.Columns {
+			cell := "-"
+			if v, ok := row.Values[c]; ok {
+				if t.Delta {
+					cell = formatDelta(0, v)
+				} else {
+					cell = formatNumber(v)
+				}
+			}
+			sb.WriteString(fmt.Sprintf(" %14s", cell))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func pivotLabel(name, param string) string {
+	segments := strings.Split(name, "/")
+	kept := segments[:0:0]
+	for _, seg := range segments {
+		if key, _, found := strings.Cut(seg, "="); found && key == param {
+			continue
+		}
+		kept = append(kept, seg)
+	}
+	return strings.Join(kept, "/")
+}
+
+func sortParamValues(values []string) {
+	slices.SortStableFunc(values, func(a, b string) int {
+		fa, errA := strconv.ParseFloat(a, 64)
+		fb, errB := strconv.ParseFloat(b, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if fa < fb {
+				return -1
+			} else if fa > fb {
+				return 1
+			}
+			return 0
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+}
@@ -3,59 +3,75 @@ package bench
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
-	"unsafe"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-var (
-	prefixGoos      = []byte("goos:")
-	prefixGoarch    = []byte("goarch:")
-	prefixPkg       = []byte("pkg:")
-	prefixBenchmark = []byte("Benchmark")
-	prefixPASS      = []byte("PASS")
-	prefixFAIL      = []byte("FAIL")
-	prefixOk        = []byte("ok")
+const (
+	prefixGoos       = "goos:"
+	prefixBenchmark  = "Benchmark"
+	prefixPASS       = "PASS"
+	prefixFAIL       = "FAIL"
+	prefixOk         = "ok"
+	prefixPanic      = "panic: "
+	prefixResult     = "--- "
+	prefixExitStatus = "exit status "
 )
 
 type Parser struct {
-	goVersion string
+	diagnostics
+	goVersion  string
+	modulePath string
+	handler    func(Event)
+
+	inSuite   bool
+	ending    bool
+	status    string
+	elapsed   float64
+	names     []string
+	running   string
+	previous  string
+	output    []string
+	target    *Benchmark
+	targetLog bool
+	panicking bool
 }
 
 func NewParser() *Parser {
 	return &Parser{}
 }
 
-func (p *Parser) Parse(r io.Reader) ([]Suite, error) {
-	br := bufio.NewReader(r)
+func (p *Parser) SetGoVersion(version string) {
+	p.goVersion = version
+}
 
-	suites := make([]Suite, 0, 4)
+func (p *Parser) SetModulePath(module string) {
+	p.modulePath = module
+}
 
-	for {
-		line, isPrefix, err := br.ReadLine()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		if isPrefix {
-			return nil, fmt.Errorf("line too long")
-		}
+func (p *Parser) ModulePath() string {
+	return p.modulePath
+}
 
-		if len(line) > 0 && line[0] == 'g' && bytes.HasPrefix(line, prefixGoos) {
-			suite, err := p.readBenchmarkSuite(br, line)
-			if err != nil {
-				return nil, err
-			}
-			suites = append(suites, *suite)
-		}
+func (p *Parser) OnEvent(fn func(Event)) {
+	p.handler = fn
+}
+
+func (p *Parser) Parse(r io.Reader) ([]Suite, error) {
+	br := bufio.NewReader(r)
+	if isTestJSON(br) {
+		return p.parseTestJSON(br)
 	}
 
-	return suites, nil
+	return p.collect(br)
 }
 
 func (p *Parser) ParseBytes(data []byte) ([]Suite, error) {
@@ -76,261 +92,332 @@ func (p *Parser) ParseStdin() ([]Suite, error) {
 	return p.Parse(os.Stdin)
 }
 
-func (p *Parser) readBenchmarkSuite(br *bufio.Reader, firstLine []byte) (*Suite, error) {
-	lineStr := bytesToString(firstLine)
-	_, value, found := strings.Cut(lineStr, ": ")
-	if !found {
-		return nil, fmt.Errorf("invalid goos line: %s", lineStr)
+func (p *Parser) collect(r io.Reader) ([]Suite, error) {
+	c := NewCollector()
+	c.SetModulePath(p.modulePath)
+	handler := p.handler
+	p.handler = func(ev Event) {
+		c.Handle(ev)
+		if handler != nil {
+			handler(ev)
+		}
 	}
+	defer func() { p.handler = handler }()
 
-	suite := Suite{
-		Goos:       strings.TrimSpace(value),
-		Benchmarks: make([]Benchmark, 0, 32),
+	if err := p.Stream(r); err != nil {
+		return nil, err
 	}
+	return c.Suites(), nil
+}
 
-	if p.goVersion != "" {
-		suite.Go = p.goVersion
-	}
+func (p *Parser) Stream(r io.Reader) error {
+	p.lineNo = 0
+	p.endSuite()
 
+	br := bufio.NewReader(r)
 	for {
-		line, isPrefix, err := br.ReadLine()
-		if err == io.EOF {
-			return &suite, nil
+		line, err := br.ReadString('\n')
+		if line != "" {
+			if perr := p.ParseLine(line); perr != nil {
+				return perr
+			}
 		}
-		if err != nil {
-			return nil, err
+		if errors.Is(err, io.EOF) {
+			break
 		}
-		if isPrefix {
-			return nil, fmt.Errorf("line too long")
+		if err != nil {
+			return err
 		}
+	}
 
-		if len(line) == 0 {
-			continue
-		}
+	p.Close()
+	return nil
+}
 
-		switch line[0] {
-		case 'P':
-			if bytes.HasPrefix(line, prefixPASS) {
-				return &suite, nil
-			}
-		case 'F':
-			if bytes.HasPrefix(line, prefixFAIL) {
-				return &suite, nil
-			}
-		case 'o':
-			if bytes.HasPrefix(line, prefixOk) {
-				return &suite, nil
-			}
-		case 'g':
-			if bytes.HasPrefix(line, prefixGoarch) {
-				lineStr := bytesToString(line)
-				if _, value, found := strings.Cut(lineStr, ": "); found {
-					suite.Goarch = strings.TrimSpace(value)
-				}
-			}
-		case 'p':
-			if bytes.HasPrefix(line, prefixPkg) {
-				lineStr := bytesToString(line)
-				if _, value, found := strings.Cut(lineStr, ": "); found {
-					suite.Pkg = strings.TrimSpace(value)
-				}
-			}
-		case 'B':
-			if bytes.HasPrefix(line, prefixBenchmark) {
-				lineStr := bytesToString(line)
-				bench, err := p.parseBenchmark(lineStr)
-				if err != nil {
-					return nil, fmt.Errorf("%w: %q", err, lineStr)
-				}
-				suite.Benchmarks = append(suite.Benchmarks, *bench)
-			}
-		}
+func (p *Parser) Close() {
+	if p.inSuite {
+		p.emit(Event{Kind: EventSuiteEnd, Status: p.status, Elapsed: p.elapsed})
 	}
+	p.endSuite()
 }
 
-func (p *Parser) parseBenchmark(line string) (*Benchmark, error) {
-	parts := strings.Split(line, "\t")
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("invalid benchmark format: expected at least 3 fields, got %d", len(parts))
+func (p *Parser) ParseLine(line string) error {
+	p.lineNo++
+	line = strings.TrimRight(line, "\r\n")
+
+	if p.ending {
+		if line == "" || strings.HasPrefix(line, prefixExitStatus) {
+			return nil
+		}
+		if p.finish(p.status, line) {
+			return nil
+		}
+		p.Close()
 	}
 
-	bench := &Benchmark{
-		Name: strings.TrimSpace(parts[0]),
+	if !p.inSuite {
+		if strings.HasPrefix(line, prefixGoos) {
+			return p.startSuite(line)
+		}
+		return nil
 	}
 
-	runs, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("%s: could not parse runs: %w", bench.Name, err)
+	if line == "" {
+		return nil
 	}
-	bench.Runs = runs
 
-	for i := 2; i < len(parts); i++ {
-		if err := p.parseMetric(bench, strings.TrimSpace(parts[i])); err != nil {
-			return nil, err
+	if p.panicking {
+		switch {
+		case strings.HasPrefix(line, prefixFAIL), strings.HasPrefix(line, prefixOk):
+			p.finish(StatusPanicked, line)
+		case !strings.HasPrefix(line, prefixExitStatus):
+			p.emit(Event{Kind: EventFailure, Text: line})
 		}
+		return nil
 	}
 
-	return bench, nil
-}
-
-func (p *Parser) parseMetric(bench *Benchmark, metric string) error {
-	valueStr, unit, found := strings.Cut(metric, " ")
-	if !found {
-		return fmt.Errorf("%s: invalid metric format: %s", bench.Name, metric)
+	if line[0] == ' ' || line[0] == '\t' {
+		p.parseOutputLine(strings.TrimSpace(line))
+		return nil
 	}
 
-	value, err := strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return fmt.Errorf("%s: could not parse value: %w", bench.Name, err)
+	if strings.HasPrefix(line, prefixPanic) {
+		p.panicking = true
+		p.status = StatusPanicked
+		p.emit(Event{Kind: EventFailure, Text: line})
+		if p.running != "" {
+			name, procs := SplitProcs(p.running)
+			p.emitBenchmark(newFailedBenchmark(name, procs, StatusPanicked, append(p.output, line)))
+		}
+		return nil
 	}
 
-	switch unit {
-	case "ns/op":
-		bench.NsPerOp = value
-	case "B/op":
-		if bench.Mem == nil {
-			bench.Mem = &Mem{}
+	if line[0] >= 'a' && line[0] <= 'z' {
+		if key, value, ok := parseConfigLine(line); ok {
+			p.emit(Event{Kind: EventConfig, Key: key, Value: value})
+			return nil
 		}
-		bench.Mem.BytesPerOp = value
-	case "allocs/op":
-		if bench.Mem == nil {
-			bench.Mem = &Mem{}
-		}
-		bench.Mem.AllocsPerOp = value
-	case "MB/s":
-		if bench.Mem == nil {
-			bench.Mem = &Mem{}
-		}
-		bench.Mem.MBPerSec = value
-	default:
-		if bench.Custom == nil {
-			bench.Custom = make(map[string]float64, 4)
-		}
-		bench.Custom[unit] = value
+	}
+
+	p.target = nil
+	last := p.previous
+	p.previous = ""
+
+	switch {
+	case strings.HasPrefix(line, prefixPASS), strings.HasPrefix(line, prefixOk):
+		p.finish(StatusOK, line)
+	case strings.HasPrefix(line, prefixFAIL):
+		p.finish(StatusFailed, line)
+	case strings.HasPrefix(line, prefixResult):
+		p.parseResultLine(line)
+	case strings.HasPrefix(line, prefixBenchmark):
+		return p.parseResult(line, last)
 	}
 
 	return nil
 }
 
-func (p *Parser) SetGoVersion(version string) {
-	p.goVersion = version
+func (p *Parser) startSuite(line string) error {
+	_, value, found := strings.Cut(line, ": ")
+	if !found {
+		return p.report(line, "invalid goos line")
+	}
+
+	p.inSuite = true
+	p.emit(Event{Kind: EventSuiteStart, Suite: &Suite{
+		Go:         p.goVersion,
+		Goos:       strings.TrimSpace(value),
+		Benchmarks: make([]Benchmark, 0, 32),
+	}})
+	return nil
 }
 
-func bytesToString(b []byte) string {
-	if len(b) == 0 {
-		return ""
+func (p *Parser) finish(status, line string) bool {
+	p.status = status
+	elapsed, ok := parsePackageSummary(line)
+	if !ok {
+		p.ending = true
+		return false
 	}
-	return unsafe.String(&b[0], len(b))
+
+	p.elapsed = elapsed
+	p.Close()
+	return true
 }
 
-type StreamingParser struct {
-	currentSuite *Suite
-	goVersion    string
+func (p *Parser) endSuite() {
+	p.inSuite = false
+	p.ending = false
+	p.status = ""
+	p.elapsed = 0
+	p.names = nil
+	p.running = ""
+	p.previous = ""
+	p.output = nil
+	p.target = nil
+	p.panicking = false
 }
 
-func NewStreamingParser() *StreamingParser {
-	return &StreamingParser{}
+func (p *Parser) parseOutputLine(text string) {
+	switch {
+	case p.target != nil && p.targetLog:
+		p.emit(Event{Kind: EventLog, Name: p.target.Name, Procs: p.target.Procs, Text: text})
+	case p.target != nil:
+		p.emit(Event{Kind: EventFailure, Name: p.target.Name, Procs: p.target.Procs, Text: text})
+	case p.running != "" || p.previous != "":
+		p.output = append(p.output, text)
+	}
 }
 
-func (p *StreamingParser) ParseLine(line string) (*Suite, *Benchmark, error) {
-	line = strings.TrimSpace(line)
-	if line == "" {
-		return nil, nil, nil
+func (p *Parser) parseResultLine(line string) {
+	status, name, ok := parseResultHeader(line)
+	if !ok {
+		return
 	}
+	if base, _ := SplitProcs(name); base != p.running {
+		p.output = nil
+	}
+	p.running = ""
 
-	switch {
-	case strings.HasPrefix(line, "goos:"):
-		_, value, found := strings.Cut(line, ":")
-		if !found {
-			return nil, nil, nil
-		}
-		p.currentSuite = &Suite{
-			Goos:       strings.TrimSpace(value),
-			Benchmarks: make([]Benchmark, 0, 32),
-		}
-		if p.goVersion != "" {
-			p.currentSuite.Go = p.goVersion
-		}
-		return p.currentSuite, nil, nil
+	name, procs := SplitProcs(name)
+	if status == StatusOK {
+		p.target = &Benchmark{Name: name, Procs: procs}
+		p.targetLog = true
+		return
+	}
+	if len(p.output) == 0 && p.hasSubBenchmarks(name) {
+		return
+	}
 
-	case strings.HasPrefix(line, "goarch:"):
-		if p.currentSuite != nil {
-			_, value, _ := strings.Cut(line, ":")
-			p.currentSuite.Goarch = strings.TrimSpace(value)
-		}
-		return nil, nil, nil
+	p.emitBenchmark(newFailedBenchmark(name, procs, status, p.output))
+	p.target = &Benchmark{Name: name, Procs: procs}
+	p.targetLog = false
+	p.output = nil
+}
 
-	case strings.HasPrefix(line, "pkg:"):
-		if p.currentSuite != nil {
-			_, value, _ := strings.Cut(line, ":")
-			p.currentSuite.Pkg = strings.TrimSpace(value)
-		}
-		return nil, nil, nil
+func (p *Parser) parseResult(line, last string) error {
+	if name, ok := bareBenchmarkName(line); ok {
+		p.running = name
+		p.output = nil
+		return nil
+	}
 
-	case strings.HasPrefix(line, "Benchmark"):
-		bench, err := p.parseBenchmarkLine(line)
-		return nil, bench, err
+	bench, err := p.parseBenchmarkLine(line)
+	if err != nil || bench == nil {
+		return err
+	}
+	if base, _ := SplitProcs(p.running); base == bench.Name || last == bench.Name {
+		bench.Log = p.output
+	}
+	p.emitBenchmark(*bench)
+	p.running = ""
+	p.previous = bench.Name
+	p.output = nil
+	return nil
+}
+
+func (p *Parser) hasSubBenchmarks(name string) bool {
+	for _, n := range p.names {
+		if strings.HasPrefix(n, name+"/") {
+			return true
+		}
 	}
+	return false
+}
+
+func (p *Parser) emitBenchmark(b Benchmark) {
+	p.names = append(p.names, b.Name)
+	p.emit(Event{Kind: EventBenchmark, Benchmark: &b})
+}
 
-	return nil, nil, nil
+func (p *Parser) emit(ev Event) {
+	if p.handler == nil {
+		return
+	}
+	ev.Line = p.line()
+	p.handler(ev)
 }
 
-func (p *StreamingParser) parseBenchmarkLine(line string) (*Benchmark, error) {
-	parts := strings.Split(line, "\t")
-	if len(parts) < 3 {
-		return nil, fmt.Errorf("invalid benchmark format: expected at least 3 fields, got %d", len(parts))
+func parseResultHeader(line string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(line, "--- ")
+	if !ok {
+		return "", "", false
 	}
 
-	bench := &Benchmark{
-		Name: strings.TrimSpace(parts[0]),
+	result, name, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return "", "", false
 	}
+	name = strings.TrimSpace(name)
+	if i := strings.Index(name, " ("); i >= 0 {
+		name = name[:i]
+	}
+
+	switch result {
+	case "FAIL":
+		return StatusFailed, name, true
+	case "SKIP":
+		return StatusSkipped, name, true
+	case "BENCH", "PASS":
+		return StatusOK, name, true
+	}
+	return "", "", false
+}
 
-	runs, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+func parsePackageSummary(line string) (float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || (fields[0] != prefixOk && fields[0] != prefixFAIL) {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(fields[len(fields)-1])
 	if err != nil {
-		return nil, fmt.Errorf("%s: could not parse runs: %w", bench.Name, err)
+		return 0, true
 	}
-	bench.Runs = runs
+	return d.Seconds(), true
+}
 
-	for i := 2; i < len(parts); i++ {
-		metric := strings.TrimSpace(parts[i])
-		if metric == "" {
-			continue
-		}
-		valueStr, unit, found := strings.Cut(metric, " ")
-		if !found {
-			continue
-		}
+func newFailedBenchmark(name string, procs int, status string, output []string) Benchmark {
+	path, params := ParsePath(name)
+	return Benchmark{
+		Name:    name,
+		Procs:   procs,
+		Path:    path,
+		Params:  params,
+		Status:  status,
+		Failure: slices.Clone(output),
+	}
+}
 
-		value, err := strconv.ParseFloat(valueStr, 64)
-		if err != nil {
-			continue
-		}
+func parseConfigLine(line string) (string, string, bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found || key == "" || (value != "" && value[0] != ' ' && value[0] != '\t') {
+		return "", "", false
+	}
 
-		switch unit {
-		case "ns/op":
-			bench.NsPerOp = value
-		case "B/op":
-			if bench.Mem == nil {
-				bench.Mem = &Mem{}
-			}
-			bench.Mem.BytesPerOp = value
-		case "allocs/op":
-			if bench.Mem == nil {
-				bench.Mem = &Mem{}
-			}
-			bench.Mem.AllocsPerOp = value
-		case "MB/s":
-			if bench.Mem == nil {
-				bench.Mem = &Mem{}
-			}
-			bench.Mem.MBPerSec = value
-		default:
-			if bench.Custom == nil {
-				bench.Custom = make(map[string]float64, 4)
-			}
-			bench.Custom[unit] = value
+	r, _ := utf8.DecodeRuneInString(key)
+	if !unicode.IsLower(r) {
+		return "", "", false
+	}
+	for _, r := range key {
+		if unicode.IsSpace(r) || unicode.IsUpper(r) {
+			return "", "", false
 		}
 	}
 
-	return bench, nil
+	return key, strings.TrimSpace(value), true
+}
+
+func SplitProcs(name string) (string, int) {
+	i := strings.LastIndexByte(name, '-')
+	if i <= 0 || i == len(name)-1 {
+		return name, 0
+	}
+
+	procs, err := strconv.Atoi(name[i+1:])
+	if err != nil || procs <= 0 {
+		return name, 0
+	}
+
+	return name[:i], procs
 }
@@ -0,0 +1,117 @@
+package bench
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func parseSuite(t *testing.T, lines ...string) Suite {
+	t.Helper()
+	input := "goos: linux\ngoarch: amd64\npkg: example.com/p\n" + strings.Join(lines, "\n") + "\nPASS\nok  \texample.com/p\t1.000s\n"
+	suites, err := NewParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 1 {
+		t.Fatalf("got %d suites, want 1", len(suites))
+	}
+	return suites[0]
+}
+
+func TestParserMergesCountSamples(t *testing.T) {
+	s := parseSuite(t,
+		"BenchmarkEncode-8   \t    1000\t       100 ns/op\t      16 B/op\t       1 allocs/op",
+		"BenchmarkEncode-8   \t    3000\t       200 ns/op\t      16 B/op\t       1 allocs/op",
+	)
+
+	if len(s.Benchmarks) != 1 {
+		t.Fatalf("got %d benchmarks, want 1", len(s.Benchmarks))
+	}
+	b := s.Benchmarks[0]
+	if b.Name != "BenchmarkEncode" || b.Procs != 8 {
+		t.Errorf("name = %s procs = %d, want BenchmarkEncode 8", b.Name, b.Procs)
+	}
+	if len(b.Samples) != 2 || b.Runs != 2000 || b.NsPerOp != 150 {
+		t.Errorf("samples = %d runs = %d ns/op = %v, want 2 2000 150", len(b.Samples), b.Runs, b.NsPerOp)
+	}
+	if st := b.Stats[UnitNsPerOp]; st.N != 2 || st.Min != 100 || st.Max != 200 {
+		t.Errorf("ns/op stats = %+v, want n=2 min=100 max=200", st)
+	}
+	if got := b.Values(UnitNsPerOp); !slices.Equal(got, []float64{100, 200}) {
+		t.Errorf("ns/op values = %v, want [100 200]", got)
+	}
+}
+
+func TestParserSplitsProcsSuffix(t *testing.T) {
+	s := parseSuite(t,
+		"BenchmarkEncode     \t    1000\t       400 ns/op",
+		"BenchmarkEncode-4   \t    1000\t       100 ns/op",
+		"BenchmarkEncode     \t    1000\t       420 ns/op",
+		"BenchmarkEncode-4   \t    1000\t       110 ns/op",
+	)
+
+	var names []string
+	for _, b := range s.Benchmarks {
+		names = append(names, b.FullName())
+		if b.Name != "BenchmarkEncode" || b.SampleCount() != 2 {
+			t.Errorf("%s: name = %s samples = %d, want BenchmarkEncode 2", b.FullName(), b.Name, b.SampleCount())
+		}
+	}
+	if want := []string{"BenchmarkEncode", "BenchmarkEncode-4"}; !slices.Equal(names, want) {
+		t.Errorf("benchmarks = %v, want %v", names, want)
+	}
+}
+
+func TestParserRecordsReportedUnits(t *testing.T) {
+	s := parseSuite(t,
+		"BenchmarkCopy-8     \t    1000\t        25.00 ns/op\t40000.00 MB/s\t         3.000 widgets/op\t       0 B/op\t       0 allocs/op",
+		"BenchmarkLegacy-8   \t    1000\t        25.00 ns/op\t40000.00 MB/s",
+	)
+
+	copyBench, legacy := s.Benchmarks[0], s.Benchmarks[1]
+	if want := []string{UnitNsPerOp, UnitMBPerSec, "widgets/op", UnitBytesPerOp, UnitAllocsPerOp}; !slices.Equal(copyBench.Units, want) {
+		t.Errorf("units = %v, want %v", copyBench.Units, want)
+	}
+	metrics := copyBench.Metrics()
+	for unit, want := range map[string]float64{UnitBytesPerOp: 0, UnitAllocsPerOp: 0, "widgets/op": 3, UnitMBPerSec: 40000} {
+		if got, ok := metrics[unit]; !ok || got != want {
+			t.Errorf("%s = %v (present %v), want %v", unit, got, ok, want)
+		}
+	}
+
+	if _, ok := legacy.Metrics()[UnitBytesPerOp]; ok {
+		t.Error("B/op reported for a benchmark run without -benchmem")
+	}
+}
+
+func TestBenchmarkMergeDoesNotShareSlices(t *testing.T) {
+	base := Benchmark{Name: "BenchmarkEncode", Log: make([]string, 1, 4)}
+
+	first, second := base, base
+	first.merge(Benchmark{Log: []string{"first"}})
+	second.merge(Benchmark{Log: []string{"second"}})
+
+	if first.Log[1] != "first" || second.Log[1] != "second" {
+		t.Errorf("logs = %q and %q, want independent copies", first.Log, second.Log)
+	}
+}
+
+func TestMetricsKeepsZeroThroughput(t *testing.T) {
+	s := parseSuite(t, "BenchmarkIdle-8     \t    1000\t        25.00 ns/op\t       0 MB/s")
+
+	var buf strings.Builder
+	if err := EncodeRuns(&buf, []Run{{Suites: []Suite{s}}}); err != nil {
+		t.Fatal(err)
+	}
+	runs, err := DecodeRuns(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range []Benchmark{s.Benchmarks[0], runs[0].Suites[0].Benchmarks[0]} {
+		if v, ok := b.Metrics()[UnitMBPerSec]; !ok || v != 0 {
+			t.Errorf("MB/s = %v (present %v), want a reported 0", v, ok)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const DefaultRuleName = "default"
+
+type Policy struct {
+	Ignore      []string         `json:"ignore,omitempty"`
+	Rules       []PolicyRule     `json:"rules,omitempty"`
+	Environment *StabilityPolicy `json:"environment,omitempty"`
+
+	ignore []*regexp.Regexp
+}
+
+type PolicyRule struct {
+	Name       string             `json:"name,omitempty"`
+	Match      string             `json:"match"`
+	Threshold  *float64           `json:"threshold,omitempty"`
+	Thresholds map[string]float64 `json:"thresholds,omitempty"`
+	Budgets    map[string]float64 `json:"budgets,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+var metricAliases = map[string]string{
+	"nsPerOp":     UnitNsPerOp,
+	"bytesPerOp":  UnitBytesPerOp,
+	"allocsPerOp": UnitAllocsPerOp,
+	"mbPerSec":    UnitMBPerSec,
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy file: %w", err)
+	}
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("error decoding policy file: %w", err)
+	}
+
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (p *Policy) compile() error {
+	p.ignore = make([]*regexp.Regexp, 0, len(p.Ignore))
+	for _, pattern := range p.Ignore {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		p.ignore = append(p.ignore, re)
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Match == "" {
+			return fmt.Errorf("rule %d: match is required", i)
+		}
+
+		re, err := compilePattern(rule.Match)
+		if err != nil {
+			return fmt.Errorf("rule %d: invalid match pattern %q: %w", i, rule.Match, err)
+		}
+		rule.pattern = re
+		rule.Thresholds = normalizeMetricKeys(rule.Thresholds)
+		rule.Budgets = normalizeMetricKeys(rule.Budgets)
+	}
+
+	return nil
+}
+
+func (p *Policy) Apply(results []ComparisonResult) []ComparisonResult {
+	applied := make([]ComparisonResult, 0, len(results))
+
+	for _, r := range results {
+		if p.ignored(r) {
+			continue
+		}
+
+		rule := p.ruleFor(r)
+		if rule == nil {
+			r.Rule = DefaultRuleName
+			applied = append(applied, r)
+			continue
+		}
+
+		r.Rule = rule.displayName()
+		r.Threshold = rule.Threshold
+		r.Thresholds = rule.Thresholds
+
+		if r.Kind != ComparisonRemoved {
+			for _, unit := range slices.Sorted(maps.Keys(rule.Budgets)) {
+				if v, ok := budgetViolation(r, unit, rule.Budgets[unit]); ok {
+					r.BudgetViolations = append(r.BudgetViolations, v)
+				}
+			}
+		}
+
+		applied = append(applied, r)
+	}
+
+	return applied
+}
+
+func budgetViolation(r ComparisonResult, unit string, budget float64) (string, bool) {
+	value, ok := r.NewValue(unit)
+	if !ok {
+		return "", false
+	}
+	if DirectionOf(unit) == HigherIsBetter {
+		if value < budget {
+			return fmt.Sprintf("%s %s < %s", unit, formatNumber(value), formatNumber(budget)), true
+		}
+		return "", false
+	}
+	if value > budget {
+		return fmt.Sprintf("%s %s > %s", unit, formatNumber(value), formatNumber(budget)), true
+	}
+	return "", false
+}
+
+func (p *Policy) ignored(r ComparisonResult) bool {
+	for _, re := range p.ignore {
+		if matchResult(re, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) ruleFor(r ComparisonResult) *PolicyRule {
+	for i := range p.Rules {
+		if matchResult(p.Rules[i].pattern, r) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (r *PolicyRule) displayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Match
+}
+
+func matchResult(re *regexp.Regexp, r ComparisonResult) bool {
+	if re.MatchString(r.Name) {
+		return true
+	}
+	return r.Pkg != "" && re.MatchString(strings.TrimPrefix(r.Name, r.Pkg+"/"))
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if expr, ok := strings.CutPrefix(pattern, "re:"); ok {
+		return regexp.Compile(expr)
+	}
+	return regexp.Compile(globToRegexp(pattern))
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+func normalizeMetricKeys(m map[string]float64) map[string]float64 {
+	if len(m) == 0 {
+		return nil
+	}
+	normalized := make(map[string]float64, len(m))
+	for k, v := range m {
+		normalized[NormalizeMetric(k)] = v
+	}
+	return normalized
+}
+
+func NormalizeMetric(name string) string {
+	if unit, ok := metricAliases[name]; ok {
+		return unit
+	}
+	return name
+}
+
+func formatNumber(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
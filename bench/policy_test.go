@@ -0,0 +1,42 @@
+package bench
+
+import (
+	"slices"
+	"testing"
+)
+
+func budgetResult(unit string, value float64) ComparisonResult {
+	return ComparisonResult{
+		Name:    "BenchmarkEncode",
+		Metrics: []MetricComparison{{Unit: unit, New: value, HasNew: true}},
+	}
+}
+
+func applyBudget(t *testing.T, unit string, budget float64, r ComparisonResult) []string {
+	t.Helper()
+	p := Policy{Rules: []PolicyRule{{Match: "BenchmarkEncode", Budgets: map[string]float64{unit: budget}}}}
+	if err := p.compile(); err != nil {
+		t.Fatal(err)
+	}
+	return p.Apply([]ComparisonResult{r})[0].BudgetViolations
+}
+
+func TestBudgetLowerIsBetterIsCeiling(t *testing.T) {
+	if v := applyBudget(t, "nsPerOp", 500, budgetResult(UnitNsPerOp, 400)); len(v) != 0 {
+		t.Errorf("400 ns/op under a 500 budget: got violations %v", v)
+	}
+	want := []string{"ns/op 600 > 500"}
+	if v := applyBudget(t, "nsPerOp", 500, budgetResult(UnitNsPerOp, 600)); !slices.Equal(v, want) {
+		t.Errorf("600 ns/op over a 500 budget: got %v, want %v", v, want)
+	}
+}
+
+func TestBudgetHigherIsBetterIsFloor(t *testing.T) {
+	if v := applyBudget(t, "mbPerSec", 100, budgetResult(UnitMBPerSec, 150)); len(v) != 0 {
+		t.Errorf("150 MB/s above a 100 budget: got violations %v", v)
+	}
+	want := []string{"MB/s 80 < 100"}
+	if v := applyBudget(t, "mbPerSec", 100, budgetResult(UnitMBPerSec, 80)); !slices.Equal(v, want) {
+		t.Errorf("80 MB/s below a 100 budget: got %v, want %v", v, want)
+	}
+}
@@ -0,0 +1,183 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	StabilityWarn   = "warn"
+	StabilityRefuse = "refuse"
+)
+
+type Stability struct {
+	Governor     string   `json:"governor,omitempty"`
+	Boost        string   `json:"boost,omitempty"`
+	Load         float64  `json:"load,omitempty"`
+	OnlineCPUs   int      `json:"onlineCPUs,omitempty"`
+	PresentCPUs  int      `json:"presentCPUs,omitempty"`
+	MemAvailable float64  `json:"memAvailable,omitempty"`
+	MemPressure  float64  `json:"memPressure,omitempty"`
+	Issues       []string `json:"issues,omitempty"`
+}
+
+type StabilityPolicy struct {
+	Governors       []string `json:"governors,omitempty"`
+	AllowBoost      bool     `json:"allowBoost,omitempty"`
+	MaxLoad         *float64 `json:"maxLoad,omitempty"`
+	MinMemAvailable *float64 `json:"minMemAvailable,omitempty"`
+	MaxMemPressure  *float64 `json:"maxMemPressure,omitempty"`
+}
+
+func DefaultStabilityPolicy() StabilityPolicy {
+	return StabilityPolicy{
+		Governors:       []string{"performance"},
+		MaxLoad:         stabilityLimit(0.1),
+		MinMemAvailable: stabilityLimit(10),
+		MaxMemPressure:  stabilityLimit(1),
+	}
+}
+
+func stabilityLimit(v float64) *float64 {
+	return &v
+}
+
+func (p StabilityPolicy) withDefaults() StabilityPolicy {
+	defaults := DefaultStabilityPolicy()
+	if len(p.Governors) == 0 {
+		p.Governors = defaults.Governors
+	}
+	if p.MaxLoad == nil {
+		p.MaxLoad = defaults.MaxLoad
+	}
+	if p.MinMemAvailable == nil {
+		p.MinMemAvailable = defaults.MinMemAvailable
+	}
+	if p.MaxMemPressure == nil {
+		p.MaxMemPressure = defaults.MaxMemPressure
+	}
+	return p
+}
+
+func (s *Stability) Stable() bool {
+	return len(s.Issues) == 0
+}
+
+func CheckStability(policy StabilityPolicy) *Stability {
+	s := captureStability("/sys/devices/system/cpu", "/proc")
+	s.Issues = s.evaluate(policy.withDefaults())
+	return s
+}
+
+func captureStability(sysCPU, proc string) *Stability {
+	s := &Stability{
+		OnlineCPUs:  countCPUList(readFirstLine(filepath.Join(sysCPU, "online"))),
+		PresentCPUs: countCPUList(readFirstLine(filepath.Join(sysCPU, "present"))),
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(sysCPU, "cpu[0-9]*", "cpufreq", "scaling_governor"))
+	var governors []string
+	for _, path := range paths {
+		if g := readFirstLine(path); g != "" && !slices.Contains(governors, g) {
+			governors = append(governors, g)
+		}
+	}
+	slices.Sort(governors)
+	s.Governor = strings.Join(governors, ",")
+
+	if noTurbo := readFirstLine(filepath.Join(sysCPU, "intel_pstate", "no_turbo")); noTurbo != "" {
+		s.Boost = onOff(noTurbo == "0")
+	} else if boost := readFirstLine(filepath.Join(sysCPU, "cpufreq", "boost")); boost != "" {
+		s.Boost = onOff(boost == "1")
+	}
+
+	if fields := strings.Fields(readFirstLine(filepath.Join(proc, "loadavg"))); len(fields) > 0 {
+		s.Load, _ = strconv.ParseFloat(fields[0], 64)
+	}
+
+	meminfo := filepath.Join(proc, "meminfo")
+	total, okTotal := readMemInfo(meminfo, "MemTotal")
+	available, okAvailable := readMemInfo(meminfo, "MemAvailable")
+	if okTotal && okAvailable && total > 0 {
+		s.MemAvailable = math.Round(float64(available)/float64(total)*1000) / 10
+	}
+
+	for _, field := range strings.Fields(readFirstLine(filepath.Join(proc, "pressure", "memory"))) {
+		if v, ok := strings.CutPrefix(field, "avg10="); ok {
+			s.MemPressure, _ = strconv.ParseFloat(v, 64)
+		}
+	}
+
+	return s
+}
+
+func (s *Stability) evaluate(policy StabilityPolicy) []string {
+	var issues []string
+
+	for _, g := range strings.Split(s.Governor, ",") {
+		if g != "" && !slices.Contains(policy.Governors, g) {
+			issues = append(issues, fmt.Sprintf("cpu frequency governor is %s (want %s)", g, strings.Join(policy.Governors, " or ")))
+			break
+		}
+	}
+
+	if s.Boost == "on" && !policy.AllowBoost {
+		issues = append(issues, "turbo boost is enabled")
+	}
+
+	if cpus := s.OnlineCPUs; cpus > 0 && s.Load/float64(cpus) > *policy.MaxLoad {
+		issues = append(issues, fmt.Sprintf("load average %.2f is above %.2f per online CPU", s.Load, *policy.MaxLoad))
+	}
+
+	if s.OnlineCPUs > 0 && s.PresentCPUs > s.OnlineCPUs {
+		issues = append(issues, fmt.Sprintf("only %d of %d CPUs are online", s.OnlineCPUs, s.PresentCPUs))
+	}
+
+	if s.MemAvailable > 0 && s.MemAvailable < *policy.MinMemAvailable {
+		issues = append(issues, fmt.Sprintf("only %.1f%% of memory is available", s.MemAvailable))
+	}
+
+	if s.MemPressure > *policy.MaxMemPressure {
+		issues = append(issues, fmt.Sprintf("memory pressure is %.2f%%", s.MemPressure))
+	}
+
+	return issues
+}
+
+func StabilityWarnings(label string, run Run) []string {
+	if run.Stability == nil || run.Stability.Stable() {
+		return nil
+	}
+	return []string{label + " run was recorded in an unstable environment: " + strings.Join(run.Stability.Issues, ", ")}
+}
+
+func countCPUList(list string) int {
+	count := 0
+	for _, part := range strings.Split(list, ",") {
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		if !isRange {
+			hi = lo
+		}
+		start, err1 := strconv.Atoi(lo)
+		end, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil || end < start {
+			continue
+		}
+		count += end - start + 1
+	}
+	return count
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,21 @@
+package bench
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStabilityPolicyExplicitZero(t *testing.T) {
+	var policy StabilityPolicy
+	if err := json.Unmarshal([]byte(`{"maxMemPressure": 0}`), &policy); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Stability{MemPressure: 0.5}
+	if issues := s.evaluate(policy.withDefaults()); len(issues) != 1 {
+		t.Errorf("maxMemPressure 0 with 0.5%% pressure: got issues %v, want one", issues)
+	}
+	if issues := s.evaluate(StabilityPolicy{}.withDefaults()); len(issues) != 0 {
+		t.Errorf("default maxMemPressure with 0.5%% pressure: got issues %v, want none", issues)
+	}
+}
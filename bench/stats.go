@@ -0,0 +1,489 @@
+package bench
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+const (
+	UnitNsPerOp     = "ns/op"
+	UnitBytesPerOp  = "B/op"
+	UnitAllocsPerOp = "allocs/op"
+	UnitMBPerSec    = "MB/s"
+)
+
+func (s *Suite) AddBenchmark(b Benchmark) {
+	if i := s.indexOf(b.Name, b.Procs); i >= 0 {
+		s.Benchmarks[i].merge(b)
+		return
+	}
+	s.Benchmarks = append(s.Benchmarks, b)
+}
+
+func (s *Suite) indexOf(name string, procs int) int {
+	for i := range s.Benchmarks {
+		if s.Benchmarks[i].Name == name && s.Benchmarks[i].Procs == procs {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Suite) SetConfig(key, value string) {
+	switch key {
+	case "goos":
+		s.Goos = value
+	case "goarch":
+		s.Goarch = value
+	case "pkg":
+		s.Pkg = value
+	case "cpu":
+		s.CPU = value
+	default:
+		if s.Config == nil {
+			s.Config = make(map[string]string, 4)
+		}
+		s.Config[key] = value
+	}
+}
+
+func (s *Suite) SetShortPath(module string) {
+	s.ShortPath = ShortPackagePath(s.Pkg, module)
+}
+
+func (s *Suite) DisplayPath() string {
+	if s.ShortPath != "" {
+		return s.ShortPath
+	}
+	return s.Pkg
+}
+
+func (b *Benchmark) FullName() string {
+	if b.Procs > 0 {
+		return fmt.Sprintf("%s-%d", b.Name, b.Procs)
+	}
+	return b.Name
+}
+
+func (b *Benchmark) Metrics() map[string]float64 {
+	units := b.Units
+	if len(units) == 0 {
+		units = b.storedUnits()
+	}
+
+	metrics := make(map[string]float64, len(units))
+	for _, unit := range units {
+		metrics[unit] = b.metric(unit)
+	}
+	return metrics
+}
+
+func (b *Benchmark) storedUnits() []string {
+	var units []string
+	if len(b.Samples) > 0 {
+		for _, s := range b.Samples {
+			for unit := range s.Metrics {
+				if !slices.Contains(units, unit) {
+					units = append(units, unit)
+				}
+			}
+		}
+		sortUnits(units)
+		return units
+	}
+
+	if b.NsPerOp != 0 {
+		units = append(units, UnitNsPerOp)
+	}
+	if b.Mem != nil {
+		if b.Mem.BytesPerOp != 0 || b.Mem.AllocsPerOp != 0 || b.Mem.MBPerSec == 0 {
+			units = append(units, UnitBytesPerOp, UnitAllocsPerOp)
+		}
+		if b.Mem.MBPerSec != 0 {
+			units = append(units, UnitMBPerSec)
+		}
+	}
+	return append(units, slices.Sorted(maps.Keys(b.Custom))...)
+}
+
+func (b *Benchmark) SampleCount() int {
+	if len(b.Samples) > 0 {
+		return len(b.Samples)
+	}
+	return 1
+}
+
+func (b *Benchmark) Values(unit string) []float64 {
+	samples := b.samples()
+	values := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		if v, ok := s.Metrics[unit]; ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (b *Benchmark) metric(unit string) float64 {
+	if unit == UnitNsPerOp {
+		return b.NsPerOp
+	}
+	if b.Mem != nil {
+		switch unit {
+		case UnitBytesPerOp:
+			return b.Mem.BytesPerOp
+		case UnitAllocsPerOp:
+			return b.Mem.AllocsPerOp
+		case UnitMBPerSec:
+			return b.Mem.MBPerSec
+		}
+	}
+	return b.Custom[unit]
+}
+
+func (b *Benchmark) setMetric(unit string, value float64) {
+	if !slices.Contains(b.Units, unit) {
+		b.Units = append(b.Units, unit)
+	}
+
+	switch unit {
+	case UnitNsPerOp:
+		b.NsPerOp = value
+	case UnitBytesPerOp:
+		if b.Mem == nil {
+			b.Mem = &Mem{}
+		}
+		b.Mem.BytesPerOp = value
+	case UnitAllocsPerOp:
+		if b.Mem == nil {
+			b.Mem = &Mem{}
+		}
+		b.Mem.AllocsPerOp = value
+	case UnitMBPerSec:
+		if b.Mem == nil {
+			b.Mem = &Mem{}
+		}
+		b.Mem.MBPerSec = value
+	default:
+		if b.Custom == nil {
+			b.Custom = make(map[string]float64, 4)
+		}
+		b.Custom[unit] = value
+	}
+}
+
+func (b *Benchmark) samples() []Sample {
+	if len(b.Samples) > 0 {
+		return b.Samples
+	}
+	return []Sample{{Runs: b.Runs, Metrics: b.Metrics()}}
+}
+
+func (b *Benchmark) merge(other Benchmark) {
+	status := worseStatus(b.Status, other.Status)
+	failure := slices.Concat(b.Failure, other.Failure)
+	log := slices.Concat(b.Log, other.Log)
+
+	switch {
+	case !other.hasResult():
+	case !b.hasResult():
+		*b = other
+	default:
+		b.Samples = append(slices.Clone(b.samples()), other.samples()...)
+		b.aggregate()
+	}
+
+	b.Status = status
+	b.Failure = failure
+	b.Log = log
+}
+
+func (b *Benchmark) hasResult() bool {
+	return b.Runs > 0 || len(b.Samples) > 0
+}
+
+func worseStatus(a, b string) string {
+	rank := func(s string) int {
+		switch s {
+		case StatusPanicked:
+			return 3
+		case StatusFailed:
+			return 2
+		case StatusSkipped:
+			return 1
+		}
+		return 0
+	}
+	if rank(b) > rank(a) || a == "" {
+		return b
+	}
+	return a
+}
+
+func (b *Benchmark) aggregate() {
+	byUnit := make(map[string][]float64, 4)
+	var units []string
+	var totalRuns int64
+
+	for _, s := range b.Samples {
+		totalRuns += s.Runs
+		for unit, v := range s.Metrics {
+			if _, ok := byUnit[unit]; !ok {
+				units = append(units, unit)
+			}
+			byUnit[unit] = append(byUnit[unit], v)
+		}
+	}
+
+	b.Runs = int64(math.Round(float64(totalRuns) / float64(len(b.Samples))))
+	b.NsPerOp = 0
+	b.Mem = nil
+	b.Custom = nil
+	b.Units = nil
+	b.Stats = make(map[string]Stats, len(units))
+
+	sortUnits(units)
+	for _, unit := range units {
+		st := computeStats(byUnit[unit])
+		b.Stats[unit] = st
+		b.setMetric(unit, st.Mean)
+	}
+}
+
+func computeStats(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	st := Stats{
+		N:   len(sorted),
+		Min: sorted[0],
+		Max: sorted[len(sorted)-1],
+	}
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	st.Mean = sum / float64(st.N)
+	st.Median = median(sorted)
+
+	if st.N > 1 {
+		sq := 0.0
+		for _, v := range sorted {
+			d := v - st.Mean
+			sq += d * d
+		}
+		st.StdDev = math.Sqrt(sq / float64(st.N-1))
+	}
+
+	if st.Mean != 0 {
+		st.CV = st.StdDev / math.Abs(st.Mean) * 100
+	}
+
+	return st
+}
+
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+const exactMannWhitneyLimit = 400
+
+func SignificanceOf(before, after []float64, alpha float64) Significance {
+	if len(before) < 2 || len(after) < 2 {
+		return Significance{}
+	}
+
+	sig := Significance{
+		Tested: true,
+		PValue: mannWhitneyU(before, after),
+	}
+	sig.Significant = sig.PValue < alpha
+
+	if base := median(sortedCopy(before)); base != 0 {
+		shifts := pairwiseShifts(before, after)
+		sig.Shift = median(shifts) / base * 100
+		if lo, hi, ok := shiftConfidenceInterval(shifts, len(before), len(after), alpha); ok {
+			sig.CILow = lo / base * 100
+			sig.CIHigh = hi / base * 100
+		}
+	}
+
+	return sig
+}
+
+func mannWhitneyU(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	ranks, ties := rankAll(x, y)
+
+	r1 := 0.0
+	for i := 0; i < n1; i++ {
+		r1 += ranks[i]
+	}
+	u1 := r1 - float64(n1*(n1+1))/2
+	u := math.Min(u1, float64(n1*n2)-u1)
+
+	if !ties && n1*n2 <= exactMannWhitneyLimit {
+		p := 2 * mannWhitneyCDF(n1, n2)[int(math.Floor(u))]
+		return math.Min(p, 1)
+	}
+
+	n := float64(n1 + n2)
+	mu := float64(n1*n2) / 2
+	tieSum := 0.0
+	combined := sortedCopy(append(slices.Clone(x), y...))
+	for i := 0; i < len(combined); {
+		j := i
+		for j < len(combined) && combined[j] == combined[i] {
+			j++
+		}
+		t := float64(j - i)
+		tieSum += t*t*t - t
+		i = j
+	}
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	z := (math.Abs(u1-mu) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return math.Min(math.Erfc(z/math.Sqrt2), 1)
+}
+
+func rankAll(x, y []float64) ([]float64, bool) {
+	type item struct {
+		value float64
+		index int
+	}
+
+	items := make([]item, 0, len(x)+len(y))
+	for i, v := range x {
+		items = append(items, item{v, i})
+	}
+	for i, v := range y {
+		items = append(items, item{v, len(x) + i})
+	}
+	slices.SortFunc(items, func(a, b item) int {
+		switch {
+		case a.value < b.value:
+			return -1
+		case a.value > b.value:
+			return 1
+		}
+		return 0
+	})
+
+	ranks := make([]float64, len(items))
+	ties := false
+	for i := 0; i < len(items); {
+		j := i
+		for j < len(items) && items[j].value == items[i].value {
+			j++
+		}
+		if j-i > 1 {
+			ties = true
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			ranks[items[k].index] = rank
+		}
+		i = j
+	}
+
+	return ranks, ties
+}
+
+func mannWhitneyCDF(n1, n2 int) []float64 {
+	memo := make(map[[2]int][]float64)
+	var counts func(m, n int) []float64
+	counts = func(m, n int) []float64 {
+		if m == 0 || n == 0 {
+			return []float64{1}
+		}
+		key := [2]int{m, n}
+		if c, ok := memo[key]; ok {
+			return c
+		}
+		c := make([]float64, m*n+1)
+		for u, v := range counts(m-1, n) {
+			c[u+n] += v
+		}
+		for u, v := range counts(m, n-1) {
+			c[u] += v
+		}
+		memo[key] = c
+		return c
+	}
+
+	c := counts(n1, n2)
+	total := binomial(n1+n2, n1)
+	cdf := make([]float64, len(c))
+	acc := 0.0
+	for u, v := range c {
+		acc += v
+		cdf[u] = acc / total
+	}
+	return cdf
+}
+
+func pairwiseShifts(x, y []float64) []float64 {
+	shifts := make([]float64, 0, len(x)*len(y))
+	for _, a := range x {
+		for _, b := range y {
+			shifts = append(shifts, b-a)
+		}
+	}
+	slices.Sort(shifts)
+	return shifts
+}
+
+func shiftConfidenceInterval(shifts []float64, n1, n2 int, alpha float64) (float64, float64, bool) {
+	mn := n1 * n2
+
+	k := 0
+	if mn <= exactMannWhitneyLimit {
+		cdf := mannWhitneyCDF(n1, n2)
+		for k < mn && cdf[k] <= alpha/2 {
+			k++
+		}
+	} else {
+		z := math.Sqrt2 * math.Erfinv(1-alpha)
+		sd := math.Sqrt(float64(mn*(n1+n2+1)) / 12)
+		k = int(math.Floor(float64(mn)/2 - z*sd))
+	}
+	if k < 1 {
+		return 0, 0, false
+	}
+
+	return shifts[k-1], shifts[mn-k], true
+}
+
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+func sortedCopy(values []float64) []float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted
+}
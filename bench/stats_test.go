@@ -0,0 +1,81 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-4
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{"exact separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{"exact overlapping", []float64{1, 2, 3, 4, 5}, []float64{3.5, 4.5, 6, 7, 8}, 14.0 / 252},
+		{"exact symmetric", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 2.0 / 252},
+		{"normal with ties", []float64{10, 11, 11, 12, 12, 13}, []float64{12, 13, 13, 14, 15, 15}, 0.0181009},
+		{"identical samples", []float64{1, 2, 3}, []float64{1, 2, 3}, 1},
+		{"constant samples", []float64{5, 5, 5}, []float64{5, 5, 5}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mannWhitneyU(tt.x, tt.y); !approxEqual(got, tt.want) {
+				t.Errorf("p = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftConfidenceInterval(t *testing.T) {
+	wide := make([]float64, 21)
+	shifted := make([]float64, 21)
+	for i := range wide {
+		wide[i] = float64(i)
+		shifted[i] = float64(i + 10)
+	}
+
+	tests := []struct {
+		name   string
+		x, y   []float64
+		lo, hi float64
+	}{
+		{"exact", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2, 8},
+		{"normal approximation", wide, shifted, 6, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, ok := shiftConfidenceInterval(pairwiseShifts(tt.x, tt.y), len(tt.x), len(tt.y), 0.05)
+			if !ok || lo != tt.lo || hi != tt.hi {
+				t.Errorf("interval = [%v, %v] (ok=%v), want [%v, %v]", lo, hi, ok, tt.lo, tt.hi)
+			}
+		})
+	}
+
+	if _, _, ok := shiftConfidenceInterval(pairwiseShifts([]float64{1, 2}, []float64{3, 4}), 2, 2, 0.05); ok {
+		t.Error("2x2 samples cannot reach a 95% interval")
+	}
+}
+
+func TestSignificanceOf(t *testing.T) {
+	sig := SignificanceOf([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0.05)
+	if !sig.Tested || !sig.Significant {
+		t.Fatalf("got %+v, want a significant result", sig)
+	}
+	if !approxEqual(sig.Shift, 500.0/3) || !approxEqual(sig.CILow, 200.0/3) || !approxEqual(sig.CIHigh, 800.0/3) {
+		t.Errorf("shift = %.2f%% [%.2f%%, %.2f%%], want 166.67%% [66.67%%, 266.67%%]", sig.Shift, sig.CILow, sig.CIHigh)
+	}
+
+	if sig := SignificanceOf([]float64{1, 2, 3}, []float64{1, 2, 3}, 0.05); sig.Significant || sig.Shift != 0 {
+		t.Errorf("identical samples: got %+v, want no shift and not significant", sig)
+	}
+	if sig := SignificanceOf([]float64{1}, []float64{2, 3}, 0.05); sig.Tested {
+		t.Errorf("single sample: got %+v, want untested", sig)
+	}
+}
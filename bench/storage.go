@@ -23,9 +23,28 @@ func DecodeRuns(r io.Reader) ([]Run, error) {
 	if err := decoder.Decode(&runs); err != nil {
 		return nil, fmt.Errorf("error decoding JSON: %w", err)
 	}
+
+	for i := range runs {
+		for j := range runs[i].Suites {
+			normalizeBenchmarks(runs[i].Suites[j].Benchmarks)
+		}
+	}
+
 	return runs, nil
 }
 
+func normalizeBenchmarks(benchmarks []Benchmark) {
+	for i := range benchmarks {
+		b := &benchmarks[i]
+		if b.Procs == 0 {
+			b.Name, b.Procs = SplitProcs(b.Name)
+		}
+		if b.Path == nil {
+			b.Path, b.Params = ParsePath(b.Name)
+		}
+	}
+}
+
 func WriteRuns(path string, runs []Run) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -0,0 +1,248 @@
+package bench
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+type TestEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package,omitempty"`
+	Test    string  `json:"Test,omitempty"`
+	Output  string  `json:"Output,omitempty"`
+	Elapsed float64 `json:"Elapsed,omitempty"`
+}
+
+func DecodeTestEvent(line string) (TestEvent, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") {
+		return TestEvent{}, false
+	}
+
+	var ev TestEvent
+	if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Action == "" {
+		return TestEvent{}, false
+	}
+	return ev, true
+}
+
+func isTestJSON(br *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		peek, err := br.Peek(i)
+		if err != nil {
+			return false
+		}
+		switch peek[i-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		}
+		return false
+	}
+}
+
+type packageOutput struct {
+	output  strings.Builder
+	lines   []int
+	open    bool
+	status  string
+	elapsed float64
+}
+
+func (o *packageOutput) write(text string, line int) {
+	for text != "" {
+		if !o.open {
+			o.lines = append(o.lines, line)
+		}
+		chunk, rest, found := strings.Cut(text, "\n")
+		o.output.WriteString(chunk)
+		if found {
+			o.output.WriteString("\n")
+		}
+		o.open = !found
+		text = rest
+	}
+}
+
+func (p *Parser) parseTestJSON(r *bufio.Reader) ([]Suite, error) {
+	packages := make(map[string]*packageOutput)
+	var order []string
+
+	for lineNo := 1; ; lineNo++ {
+		line, err := r.ReadString('\n')
+		if strings.TrimSpace(line) != "" {
+			var ev TestEvent
+			if jerr := json.Unmarshal([]byte(line), &ev); jerr != nil {
+				return nil, fmt.Errorf("line %d: error decoding test2json event: %w", lineNo, jerr)
+			}
+			if ev.Package != "" {
+				pkg, ok := packages[ev.Package]
+				if !ok {
+					pkg = &packageOutput{}
+					packages[ev.Package] = pkg
+					order = append(order, ev.Package)
+				}
+
+				switch ev.Action {
+				case "output":
+					pkg.write(ev.Output, lineNo)
+				case "pass", "fail", "skip":
+					if ev.Test == "" {
+						pkg.status = testActionStatus(ev.Action)
+						pkg.elapsed = ev.Elapsed
+					}
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defer func() { p.lineMap = nil }()
+	suites := make([]Suite, 0, len(order))
+	for _, name := range order {
+		pkg := packages[name]
+		p.lineMap = pkg.lines
+		pkgSuites, err := p.collect(strings.NewReader(pkg.output.String()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		for i := range pkgSuites {
+			if pkgSuites[i].Pkg == "" {
+				pkgSuites[i].Pkg = name
+				pkgSuites[i].SetShortPath(p.modulePath)
+			}
+			pkgSuites[i].Status = worseStatus(pkgSuites[i].Status, pkg.status)
+			if pkg.elapsed > 0 {
+				pkgSuites[i].Elapsed = pkg.elapsed
+			}
+		}
+		suites = append(suites, pkgSuites...)
+	}
+
+	return suites, nil
+}
+
+func testActionStatus(action string) string {
+	switch action {
+	case "pass":
+		return StatusOK
+	case "fail":
+		return StatusFailed
+	case "skip":
+		return StatusSkipped
+	}
+	return ""
+}
+
+type TestJSONStream struct {
+	pending  map[string]string
+	buffered map[string][]string
+	finished map[string]bool
+	order    []string
+	active   string
+}
+
+func NewTestJSONStream() *TestJSONStream {
+	return &TestJSONStream{
+		pending:  make(map[string]string),
+		buffered: make(map[string][]string),
+		finished: make(map[string]bool),
+	}
+}
+
+func (s *TestJSONStream) Lines(line string) ([]string, bool) {
+	ev, ok := DecodeTestEvent(line)
+	if !ok {
+		return nil, false
+	}
+
+	switch ev.Action {
+	case "output":
+		text := s.pending[ev.Package] + ev.Output
+		lines := strings.Split(text, "\n")
+		s.pending[ev.Package] = lines[len(lines)-1]
+		return s.add(ev.Package, lines[:len(lines)-1]), true
+	case "pass", "fail", "skip":
+		if ev.Test == "" {
+			return s.finish(ev.Package), true
+		}
+	}
+	return nil, true
+}
+
+func (s *TestJSONStream) add(pkg string, lines []string) []string {
+	if s.active == "" {
+		s.active = pkg
+	}
+	if pkg == s.active {
+		return lines
+	}
+
+	if !slices.Contains(s.order, pkg) {
+		s.order = append(s.order, pkg)
+	}
+	s.buffered[pkg] = append(s.buffered[pkg], lines...)
+	return nil
+}
+
+func (s *TestJSONStream) finish(pkg string) []string {
+	if s.active == "" {
+		s.active = pkg
+	}
+	rest := s.pending[pkg]
+	delete(s.pending, pkg)
+
+	if pkg != s.active {
+		if rest != "" {
+			s.add(pkg, []string{rest})
+		}
+		if slices.Contains(s.order, pkg) {
+			s.finished[pkg] = true
+		}
+		return nil
+	}
+
+	var lines []string
+	if rest != "" {
+		lines = append(lines, rest)
+	}
+	s.active = ""
+	for len(s.order) > 0 {
+		next := s.order[0]
+		s.order = s.order[1:]
+		lines = append(lines, s.buffered[next]...)
+		delete(s.buffered, next)
+		if !s.finished[next] {
+			s.active = next
+			break
+		}
+		delete(s.finished, next)
+	}
+	return lines
+}
+
+func (s *TestJSONStream) Flush() []string {
+	for _, pkg := range s.order {
+		if rest := s.pending[pkg]; rest != "" {
+			s.buffered[pkg] = append(s.buffered[pkg], rest)
+		}
+		delete(s.pending, pkg)
+		s.finished[pkg] = true
+	}
+	if s.active == "" {
+		return nil
+	}
+	return s.finish(s.active)
+}
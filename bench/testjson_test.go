@@ -0,0 +1,99 @@
+package bench
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testEvent(action, pkg, output string, elapsed float64) string {
+	data, _ := json.Marshal(TestEvent{Action: action, Package: pkg, Output: output, Elapsed: elapsed})
+	return string(data)
+}
+
+func TestTestJSONStreamInterleavedPackages(t *testing.T) {
+	events := []string{
+		testEvent("start", "example.com/a", "", 0),
+		testEvent("start", "example.com/b", "", 0),
+		testEvent("output", "example.com/a", "goos: linux\n", 0),
+		testEvent("output", "example.com/b", "goos: linux\n", 0),
+		testEvent("output", "example.com/a", "goarch: amd64\n", 0),
+		testEvent("output", "example.com/b", "goarch: amd64\n", 0),
+		testEvent("output", "example.com/a", "pkg: example.com/a\n", 0),
+		testEvent("output", "example.com/b", "pkg: example.com/b\n", 0),
+		testEvent("output", "example.com/b", "BenchmarkB-8   \t", 0),
+		testEvent("output", "example.com/a", "BenchmarkA-8   \t 1000\t 100 ns/op\n", 0),
+		testEvent("output", "example.com/b", " 2000\t 200 ns/op\n", 0),
+		testEvent("output", "example.com/b", "PASS\n", 0),
+		testEvent("output", "example.com/b", "ok  \texample.com/b\t2.000s\n", 0),
+		testEvent("pass", "example.com/b", "", 2),
+		testEvent("output", "example.com/a", "PASS\n", 0),
+		testEvent("output", "example.com/a", "ok  \texample.com/a\t1.000s\n", 0),
+		testEvent("pass", "example.com/a", "", 1),
+	}
+
+	stream := NewTestJSONStream()
+	var lines []string
+	for _, ev := range events {
+		out, ok := stream.Lines(ev)
+		if !ok {
+			t.Fatalf("event not recognized: %s", ev)
+		}
+		lines = append(lines, out...)
+	}
+	lines = append(lines, stream.Flush()...)
+
+	suites, err := NewParser().Parse(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 2 {
+		t.Fatalf("got %d suites, want 2:\n%s", len(suites), strings.Join(lines, "\n"))
+	}
+
+	want := []struct {
+		pkg     string
+		bench   string
+		elapsed float64
+	}{
+		{"example.com/a", "BenchmarkA", 1},
+		{"example.com/b", "BenchmarkB", 2},
+	}
+	for i, w := range want {
+		s := suites[i]
+		if s.Pkg != w.pkg || s.Elapsed != w.elapsed {
+			t.Errorf("suite %d = %s elapsed %v, want %s elapsed %v", i, s.Pkg, s.Elapsed, w.pkg, w.elapsed)
+		}
+		if len(s.Benchmarks) != 1 || s.Benchmarks[0].Name != w.bench {
+			t.Errorf("suite %d benchmarks = %+v, want only %s", i, s.Benchmarks, w.bench)
+		}
+	}
+}
+
+func TestParseTestJSONDiagnosticLines(t *testing.T) {
+	events := []string{
+		testEvent("output", "example.com/a", "goos: linux\n", 0),
+		testEvent("output", "example.com/b", "goos: linux\n", 0),
+		testEvent("output", "example.com/a", "pkg: example.com/a\n", 0),
+		testEvent("output", "example.com/b", "pkg: example.com/b\n", 0),
+		testEvent("output", "example.com/a", "BenchmarkA-8   \t 1000\t 100 ns/op\n", 0),
+		testEvent("output", "example.com/b", "BenchmarkB-8   \t", 0),
+		testEvent("output", "example.com/a", "PASS\n", 0),
+		testEvent("output", "example.com/b", "many\t 200 ns/op\n", 0),
+		testEvent("pass", "example.com/a", "", 1),
+		testEvent("pass", "example.com/b", "", 2),
+	}
+
+	p := NewParser()
+	if _, err := p.Parse(strings.NewReader(strings.Join(events, "\n") + "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	diags := p.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("got diagnostics %v, want one", diags)
+	}
+	if diags[0].Line != 6 {
+		t.Errorf("diagnostic on line %d, want 6 (the event that started the line)", diags[0].Line)
+	}
+}
@@ -0,0 +1,106 @@
+package bench
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+var trendUnits = []string{UnitNsPerOp, UnitBytesPerOp, UnitAllocsPerOp}
+
+type BenchmarkTrend struct {
+	Pkg    string
+	Path   string
+	Name   string
+	Procs  int
+	Values map[string][]float64
+}
+
+func TrendUnits() []string {
+	return trendUnits
+}
+
+func BenchmarkHistory(runs []Run, matchProcs bool) []BenchmarkTrend {
+	perProcs := make(map[string]bool)
+	for _, run := range runs {
+		for _, s := range run.Suites {
+			counts := make(map[string]int, len(s.Benchmarks))
+			for _, b := range s.Benchmarks {
+				counts[b.Name]++
+				if counts[b.Name] > 1 {
+					perProcs[s.Pkg+"/"+b.Name] = true
+				}
+			}
+		}
+	}
+
+	var trends []BenchmarkTrend
+	for i, run := range runs {
+		for _, s := range run.Suites {
+			for _, b := range s.Benchmarks {
+				if !b.hasResult() {
+					continue
+				}
+
+				procs := 0
+				if matchProcs || perProcs[s.Pkg+"/"+b.Name] {
+					procs = b.Procs
+				}
+				j := slices.IndexFunc(trends, func(t BenchmarkTrend) bool {
+					return t.Pkg == s.Pkg && t.Name == b.Name && t.Procs == procs
+				})
+				if j < 0 {
+					trends = append(trends, newBenchmarkTrend(s, b.Name, procs, len(runs)))
+					j = len(trends) - 1
+				}
+
+				metrics := b.Metrics()
+				for _, unit := range trendUnits {
+					if v, ok := metrics[unit]; ok {
+						trends[j].Values[unit][i] = v
+					}
+				}
+			}
+		}
+	}
+	return trends
+}
+
+func newBenchmarkTrend(s Suite, name string, procs, runs int) BenchmarkTrend {
+	t := BenchmarkTrend{Pkg: s.Pkg, Path: s.DisplayPath(), Name: name, Procs: procs, Values: make(map[string][]float64, len(trendUnits))}
+	for _, unit := range trendUnits {
+		values := make([]float64, runs)
+		for i := range values {
+			values[i] = math.NaN()
+		}
+		t.Values[unit] = values
+	}
+	return t
+}
+
+func (t BenchmarkTrend) FullName() string {
+	if t.Procs > 0 {
+		return fmt.Sprintf("%s-%d", t.Name, t.Procs)
+	}
+	return t.Name
+}
+
+func (t BenchmarkTrend) Has(unit string) bool {
+	return slices.ContainsFunc(t.Values[unit], func(v float64) bool { return !math.IsNaN(v) })
+}
+
+func (t BenchmarkTrend) Change(unit string) (float64, bool) {
+	values := t.Values[unit]
+	first := slices.IndexFunc(values, func(v float64) bool { return !math.IsNaN(v) })
+	if first < 0 {
+		return 0, false
+	}
+	last := len(values) - 1
+	for math.IsNaN(values[last]) {
+		last--
+	}
+	if first == last || values[first] == 0 {
+		return 0, false
+	}
+	return (values[last] - values[first]) / values[first] * 100, true
+}
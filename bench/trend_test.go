@@ -0,0 +1,47 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func trendRun(benchmarks ...Benchmark) Run {
+	return Run{Suites: []Suite{{Pkg: "example.com/p", Benchmarks: benchmarks}}}
+}
+
+func TestBenchmarkHistoryIgnoresProcsSuffix(t *testing.T) {
+	runs := []Run{
+		trendRun(Benchmark{Name: "BenchmarkEncode", Procs: 8, Runs: 1000, NsPerOp: 100}),
+		trendRun(Benchmark{Name: "BenchmarkEncode", Procs: 16, Runs: 1000, NsPerOp: 90}),
+	}
+
+	trends := BenchmarkHistory(runs, false)
+	if len(trends) != 1 {
+		t.Fatalf("got %d trends, want 1", len(trends))
+	}
+	if got := trends[0].Values[UnitNsPerOp]; got[0] != 100 || got[1] != 90 {
+		t.Errorf("ns/op = %v, want [100 90]", got)
+	}
+
+	if trends := BenchmarkHistory(runs, true); len(trends) != 2 {
+		t.Errorf("with matchProcs: got %d trends, want 2", len(trends))
+	}
+}
+
+func TestBenchmarkHistorySplitsCPUList(t *testing.T) {
+	runs := []Run{
+		trendRun(Benchmark{Name: "BenchmarkEncode", Procs: 1, Runs: 1000, NsPerOp: 400}, Benchmark{Name: "BenchmarkEncode", Procs: 4, Runs: 1000, NsPerOp: 100}),
+		trendRun(Benchmark{Name: "BenchmarkEncode", Procs: 4, Runs: 1000, NsPerOp: 90}),
+	}
+
+	trends := BenchmarkHistory(runs, false)
+	if len(trends) != 2 {
+		t.Fatalf("got %d trends, want 2", len(trends))
+	}
+	if name := trends[1].FullName(); name != "BenchmarkEncode-4" {
+		t.Errorf("second trend = %s, want BenchmarkEncode-4", name)
+	}
+	if got := trends[0].Values[UnitNsPerOp]; got[0] != 400 || !math.IsNaN(got[1]) {
+		t.Errorf("-1 ns/op = %v, want [400 NaN]", got)
+	}
+}
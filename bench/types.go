@@ -1,27 +1,67 @@
 package bench
 
 type Run struct {
-	Version string   `json:"version,omitempty"`
-	Date    int64    `json:"date,omitempty"`
-	Tags    []string `json:"tags,omitempty"`
-	Suites  []Suite  `json:"suites"`
+	Version     string       `json:"version,omitempty"`
+	Date        int64        `json:"date,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Suites      []Suite      `json:"suites"`
+	Git         *GitInfo     `json:"git,omitempty"`
+	Environment *Environment `json:"environment,omitempty"`
+	Stability   *Stability   `json:"stability,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
 }
 
 type Suite struct {
-	Go         string      `json:"go,omitempty"`
-	Goos       string      `json:"goos"`
-	Goarch     string      `json:"goarch"`
-	ShortPath  string      `json:"short_path,omitempty"`
-	Pkg        string      `json:"pkg"`
-	Benchmarks []Benchmark `json:"benchmarks"`
+	Go         string            `json:"go,omitempty"`
+	Goos       string            `json:"goos"`
+	Goarch     string            `json:"goarch"`
+	CPU        string            `json:"cpu,omitempty"`
+	ShortPath  string            `json:"short_path,omitempty"`
+	Pkg        string            `json:"pkg"`
+	Config     map[string]string `json:"config,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Elapsed    float64           `json:"elapsed,omitempty"`
+	Failure    []string          `json:"failure,omitempty"`
+	Benchmarks []Benchmark       `json:"benchmarks"`
 }
 
+const (
+	StatusOK       = "ok"
+	StatusFailed   = "failed"
+	StatusSkipped  = "skipped"
+	StatusPanicked = "panicked"
+)
+
 type Benchmark struct {
 	Name    string             `json:"name"`
+	Procs   int                `json:"procs,omitempty"`
+	Path    []string           `json:"path,omitempty"`
+	Params  map[string]string  `json:"params,omitempty"`
+	Status  string             `json:"status,omitempty"`
+	Failure []string           `json:"failure,omitempty"`
+	Log     []string           `json:"log,omitempty"`
 	Runs    int64              `json:"runs"`
 	NsPerOp float64            `json:"nsPerOp,omitempty"`
 	Mem     *Mem               `json:"mem,omitempty"`
 	Custom  map[string]float64 `json:"custom,omitempty"`
+	Units   []string           `json:"units,omitempty"`
+	Samples []Sample           `json:"samples,omitempty"`
+	Stats   map[string]Stats   `json:"stats,omitempty"`
+}
+
+type Sample struct {
+	Runs    int64              `json:"runs"`
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+type Stats struct {
+	N      int     `json:"n"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	StdDev float64 `json:"stddev"`
+	CV     float64 `json:"cv"`
 }
 
 type Mem struct {
@@ -30,36 +70,161 @@ type Mem struct {
 	MBPerSec    float64 `json:"mbPerSec,omitempty"`
 }
 
+func IsFailure(status string) bool {
+	return status == StatusFailed || status == StatusPanicked
+}
+
+type ComparisonKind int
+
+const (
+	ComparisonMatched ComparisonKind = iota
+	ComparisonAdded
+	ComparisonRemoved
+)
+
+func (k ComparisonKind) String() string {
+	switch k {
+	case ComparisonAdded:
+		return "added"
+	case ComparisonRemoved:
+		return "removed"
+	}
+	return "matched"
+}
+
 type ComparisonResult struct {
-	Name        string
-	OldRuns     int64
-	NewRuns     int64
-	OldNsPerOp  float64
-	NewNsPerOp  float64
-	NsPerOpDiff float64
-	NsPerOpPct  float64
-	OldBytes    float64
-	NewBytes    float64
-	BytesDiff   float64
-	BytesPct    float64
-	OldAllocs   float64
-	NewAllocs   float64
-	AllocsDiff  float64
-	AllocsPct   float64
+	Name         string
+	Pkg          string
+	Params       map[string]string
+	Kind         ComparisonKind
+	OldStatus    string
+	NewStatus    string
+	Failure      []string
+	OldProcs     int
+	NewProcs     int
+	OldRuns      int64
+	NewRuns      int64
+	OldSamples   int
+	NewSamples   int
+	OldNsPerOp   float64
+	NewNsPerOp   float64
+	NsPerOpDiff  float64
+	NsPerOpPct   float64
+	OldNsPerOpCV float64
+	NewNsPerOpCV float64
+	OldBytes     float64
+	NewBytes     float64
+	BytesDiff    float64
+	BytesPct     float64
+	OldAllocs    float64
+	NewAllocs    float64
+	AllocsDiff   float64
+	AllocsPct    float64
+	Alpha        float64
+	NsPerOpSig   Significance
+	BytesSig     Significance
+	AllocsSig    Significance
+	Metrics      []MetricComparison
+
+	Rule             string
+	Threshold        *float64
+	Thresholds       map[string]float64
+	BudgetViolations []string
+}
+
+type Significance struct {
+	Tested      bool    `json:"-"`
+	PValue      float64 `json:"pValue"`
+	Shift       float64 `json:"shift"`
+	CILow       float64 `json:"ciLow"`
+	CIHigh      float64 `json:"ciHigh"`
+	Significant bool    `json:"significant"`
+}
+
+func (s Significance) Confirmed() bool {
+	return !s.Tested || s.Significant
 }
 
 func (c *ComparisonResult) IsRegression(threshold float64) bool {
-	if c.NsPerOpPct > threshold {
+	if len(c.BudgetViolations) > 0 || c.NewlyFailing() {
 		return true
 	}
 
-	if c.BytesPct > threshold {
-		return true
+	for _, m := range c.Metrics {
+		if m.IsRegression(c.ThresholdFor(m.Unit, threshold)) {
+			return true
+		}
 	}
 
-	if c.AllocsPct > threshold {
-		return true
+	return false
+}
+
+func (c *ComparisonResult) NewlyFailing() bool {
+	return c.Kind == ComparisonMatched && !IsFailure(c.OldStatus) && IsFailure(c.NewStatus)
+}
+
+func (c *ComparisonResult) IsImprovement(threshold float64) bool {
+	for _, m := range c.Metrics {
+		if m.IsImprovement(c.ThresholdFor(m.Unit, threshold)) {
+			return true
+		}
 	}
 
 	return false
 }
+
+func (c *ComparisonResult) ThresholdFor(unit string, threshold float64) float64 {
+	if t, ok := c.Thresholds[unit]; ok {
+		return t
+	}
+	if c.Threshold != nil {
+		return *c.Threshold
+	}
+	return threshold
+}
+
+func (c *ComparisonResult) Metric(unit string) (MetricComparison, bool) {
+	for _, m := range c.Metrics {
+		if m.Unit == unit {
+			return m, true
+		}
+	}
+	return MetricComparison{}, false
+}
+
+func (c *ComparisonResult) NewValue(unit string) (float64, bool) {
+	m, ok := c.Metric(unit)
+	if !ok || !m.HasNew {
+		return 0, false
+	}
+	return m.New, true
+}
+
+func (c *ComparisonResult) ExtraMetrics() []MetricComparison {
+	var extra []MetricComparison
+	for _, m := range c.Metrics {
+		if !IsCoreMetric(m.Unit) {
+			extra = append(extra, m)
+		}
+	}
+	return extra
+}
+
+func (c *ComparisonResult) fillCoreMetrics() {
+	if m, ok := c.Metric(UnitNsPerOp); ok {
+		c.OldNsPerOp, c.NewNsPerOp = m.Old, m.New
+		c.NsPerOpDiff, c.NsPerOpPct = m.Diff, m.Pct
+		c.OldNsPerOpCV, c.NewNsPerOpCV = m.OldCV, m.NewCV
+		c.NsPerOpSig = m.Sig
+	}
+	if m, ok := c.Metric(UnitBytesPerOp); ok {
+		c.OldBytes, c.NewBytes = m.Old, m.New
+		c.BytesDiff, c.BytesPct = m.Diff, m.Pct
+		c.BytesSig = m.Sig
+	}
+	if m, ok := c.Metric(UnitAllocsPerOp); ok {
+		c.OldAllocs, c.NewAllocs = m.Old, m.New
+		c.AllocsDiff, c.AllocsPct = m.Diff, m.Pct
+		c.AllocsSig = m.Sig
+	}
+}
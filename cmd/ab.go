@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mateusfdl/zeno/bench"
+	flag "github.com/spf13/pflag"
+)
+
+type ABCommand struct {
+	fs               *flag.FlagSet
+	threshold        float64
+	compareFlags     compareFlags
+	parseFlags       parseFlags
+	format           string
+	output           string
+	rounds           int
+	bench            string
+	cpu              string
+	benchtime        string
+	benchmem         bool
+	failOnRegression bool
+}
+
+type abSide struct {
+	label    string
+	ref      string
+	commit   string
+	worktree string
+	dir      string
+	binaries []testBinary
+	parser   *bench.Parser
+	suites   []bench.Suite
+}
+
+type testBinary struct {
+	pkg  string
+	dir  string
+	path string
+}
+
+func NewABCommand() *ABCommand {
+	ac := &ABCommand{
+		fs: flag.NewFlagSet("ab", flag.ContinueOnError),
+	}
+
+	ac.fs.IntVarP(&ac.rounds, "rounds", "r", 5, "Number of interleaved A/B rounds")
+	ac.fs.StringVar(&ac.bench, "bench", ".", "Run only benchmarks matching this regexp")
+	ac.fs.StringVar(&ac.cpu, "cpu", "", "GOMAXPROCS values to run with")
+	ac.fs.StringVar(&ac.benchtime, "benchtime", "", "Benchmark time or iterations per round")
+	ac.fs.BoolVar(&ac.benchmem, "benchmem", true, "Report memory allocations")
+	ac.fs.Float64VarP(&ac.threshold, "threshold", "t", 5.0, "Regression threshold percentage")
+	ac.fs.StringVarP(&ac.format, "format", "f", "table", "Output format: table or json")
+	ac.fs.StringVarP(&ac.output, "output", "o", "", "Also write both runs to this file")
+	ac.compareFlags.register(ac.fs)
+	ac.parseFlags.register(ac.fs)
+	ac.fs.BoolVar(&ac.failOnRegression, "fail-on-regression", false, "Exit with status 1 when regressions are detected")
+
+	return ac
+}
+
+func (ac *ABCommand) Run(args []string) error {
+	if err := ac.fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &ExitError{Code: ExitInputError, Err: err}
+	}
+
+	remaining := ac.fs.Args()
+	if len(remaining) < 2 {
+		return ac.inputError(fmt.Errorf("ab requires two git refs"))
+	}
+	if ac.rounds < 1 {
+		return ac.inputError(fmt.Errorf("--rounds must be at least 1"))
+	}
+	if ac.format != "table" && ac.format != "json" {
+		return ac.inputError(fmt.Errorf("unknown format: %s (use 'table' or 'json')", ac.format))
+	}
+
+	opts, err := ac.compareFlags.options()
+	if err != nil {
+		return ac.inputError(err)
+	}
+
+	packages := remaining[2:]
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	top, err := bench.GitTopLevel(dir)
+	if err != nil {
+		return ac.inputError(fmt.Errorf("ab must be run inside a git work tree: %w", err))
+	}
+	rel, err := filepath.Rel(top, dir)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "zeno-ab-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	sides := []*abSide{{label: "A", ref: remaining[0]}, {label: "B", ref: remaining[1]}}
+	for _, side := range sides {
+		side.commit, err = bench.ResolveGitRef(dir, side.ref)
+		if err != nil {
+			return ac.inputError(err)
+		}
+
+		side.worktree = filepath.Join(tmp, side.label)
+		if err := bench.AddWorktree(top, side.worktree, side.commit); err != nil {
+			return fmt.Errorf("error creating worktree for %s: %w", side.ref, err)
+		}
+		defer bench.RemoveWorktree(top, side.worktree)
+
+		side.parser, err = ac.parseFlags.parser()
+		if err != nil {
+			return ac.inputError(err)
+		}
+		side.dir = filepath.Join(side.worktree, rel)
+		fmt.Fprintf(os.Stderr, "Building %s (%s)\n", side.ref, side.commit[:min(12, len(side.commit))])
+		side.binaries, err = buildTestBinaries(side.dir, filepath.Join(tmp, "bin-"+side.label), packages)
+		if err != nil {
+			return fmt.Errorf("error building %s: %w", side.ref, err)
+		}
+	}
+
+	for round := 1; round <= ac.rounds; round++ {
+		for _, side := range sides {
+			fmt.Fprintf(os.Stderr, "Round %d/%d: %s\n", round, ac.rounds, side.ref)
+			if err := ac.runSide(side); err != nil {
+				return err
+			}
+		}
+	}
+
+	date := time.Now().Unix()
+	runs := make([]bench.Run, 0, len(sides))
+	for _, side := range sides {
+		printDiagnostics(side.parser.Diagnostics())
+		run := bench.CreateRun(side.suites, side.ref, date, []string{"ab"})
+		if git, err := bench.CaptureGit(side.worktree); err == nil {
+			run.Git = git
+		}
+		runs = append(runs, run)
+	}
+
+	results, err := bench.CompareTwoRuns(runs[0], runs[1], opts)
+	if err != nil {
+		return fmt.Errorf("error comparing benchmarks: %w", err)
+	}
+
+	if ac.output != "" {
+		if err := bench.WriteRuns(ac.output, runs); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote both runs to %s\n", ac.output)
+	}
+
+	if ac.format == "json" {
+		fmt.Println(bench.FormatComparisonAsJSON(results))
+	} else {
+		fmt.Println(bench.FormatComparisonResults(results, ac.threshold))
+	}
+
+	if ac.failOnRegression {
+		summary := bench.SummarizeComparison(results, ac.threshold)
+		if len(summary.Regressions) > 0 {
+			return &ExitError{Code: ExitRegression}
+		}
+	}
+
+	return nil
+}
+
+func (ac *ABCommand) inputError(err error) error {
+	if ac.failOnRegression {
+		return &ExitError{Code: ExitInputError, Err: err}
+	}
+	return err
+}
+
+func (ac *ABCommand) runSide(side *abSide) error {
+	for _, bin := range side.binaries {
+		cmd := exec.Command(bin.path, ac.testArgs()...)
+		cmd.Dir = bin.dir
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			return fmt.Errorf("error running %s: %w", bin.pkg, err)
+		}
+
+		suites, err := side.parser.ParseBytes(out)
+		if err != nil {
+			return fmt.Errorf("error parsing %s output: %w", bin.pkg, err)
+		}
+		for i := range suites {
+			if suites[i].Pkg == "" {
+				suites[i].Pkg = bin.pkg
+			}
+		}
+		side.suites = bench.MergeSuites(side.suites, suites)
+	}
+
+	return nil
+}
+
+func (ac *ABCommand) testArgs() []string {
+	args := []string{"-test.run", "^$", "-test.bench", ac.bench, "-test.count", "1"}
+	if ac.benchmem {
+		args = append(args, "-test.benchmem")
+	}
+	if ac.cpu != "" {
+		args = append(args, "-test.cpu", ac.cpu)
+	}
+	if ac.benchtime != "" {
+		args = append(args, "-test.benchtime", ac.benchtime)
+	}
+	return args
+}
+
+func buildTestBinaries(dir, binDir string, packages []string) ([]testBinary, error) {
+	list := exec.Command("go", append([]string{"list", "-f",
+		"{{if or .TestGoFiles .XTestGoFiles}}{{.ImportPath}}\t{{.Dir}}{{end}}"}, packages...)...)
+	list.Dir = dir
+	list.Stderr = os.Stderr
+	out, err := list.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w", err)
+	}
+
+	var binaries []testBinary
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		pkg, pkgDir, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+
+		bin := testBinary{
+			pkg:  pkg,
+			dir:  pkgDir,
+			path: filepath.Join(binDir, strconv.Itoa(len(binaries))+".test"),
+		}
+		build := exec.Command("go", "test", "-c", "-o", bin.path, pkg)
+		build.Dir = dir
+		build.Stdout = os.Stderr
+		build.Stderr = os.Stderr
+		if err := build.Run(); err != nil {
+			return nil, fmt.Errorf("go test -c %s: %w", pkg, err)
+		}
+		binaries = append(binaries, bin)
+	}
+
+	if len(binaries) == 0 {
+		return nil, fmt.Errorf("no test packages found")
+	}
+	return binaries, nil
+}
+
+func (ac *ABCommand) Usage() string {
+	return `Usage: zeno ab [options] <refA> <refB> [packages]
+
+Benchmark two git refs interleaved and compare them.
+
+Checks out both refs into temporary git worktrees, builds the test binaries
+of the given packages (default: ./...) once per side, then runs them
+alternately (A, B, A, B, ...) for --rounds rounds. Each round adds one sample
+per benchmark, so machine noise hits both sides alike, and the comparison
+applies the Mann-Whitney U test to the collected samples.
+
+Examples:
+  zeno ab main HEAD
+  zeno ab --rounds=10 --bench=Encode v1.2.0 HEAD ./encoding/...
+  zeno ab -o ab.json --fail-on-regression origin/main HEAD
+
+Options:`
+}
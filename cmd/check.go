@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mateusfdl/zeno/bench"
+	flag "github.com/spf13/pflag"
+)
+
+type CheckCommand struct {
+	fs           *flag.FlagSet
+	threshold    float64
+	compareFlags compareFlags
+	allowMissing bool
+	format       string
+	history      historyFlags
+}
+
+type checkReport struct {
+	Status string `json:"status"`
+	bench.ComparisonSummary
+}
+
+func NewCheckCommand() *CheckCommand {
+	cc := &CheckCommand{
+		fs: flag.NewFlagSet("check", flag.ContinueOnError),
+	}
+
+	cc.fs.Float64VarP(&cc.threshold, "threshold", "t", 5.0, "Regression threshold percentage")
+	cc.fs.BoolVar(&cc.allowMissing, "allow-missing", false, "Do not fail when baseline benchmarks are missing")
+	cc.fs.StringVarP(&cc.format, "format", "f", "text", "Summary format: text or json")
+	cc.history.register(cc.fs)
+	cc.compareFlags.register(cc.fs)
+
+	return cc
+}
+
+func (cc *CheckCommand) Run(args []string) error {
+	if err := cc.fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &ExitError{Code: ExitInputError, Err: err}
+	}
+
+	remaining := cc.fs.Args()
+	if len(remaining) != 2 {
+		return &ExitError{Code: ExitInputError, Err: fmt.Errorf("check requires exactly 2 file arguments (before and after)")}
+	}
+
+	if cc.format != "text" && cc.format != "json" {
+		return &ExitError{Code: ExitInputError, Err: fmt.Errorf("unknown format: %s (use 'text' or 'json')", cc.format)}
+	}
+
+	opts, err := cc.compareFlags.options()
+	if err != nil {
+		return &ExitError{Code: ExitInputError, Err: err}
+	}
+
+	before, after, err := cc.history.runPair(remaining[0], remaining[1])
+	if err != nil {
+		return cc.compareError(err)
+	}
+
+	results, err := bench.CompareTwoRuns(before, after, opts)
+	if err != nil {
+		return cc.compareError(err)
+	}
+	printWarnings(bench.EnvironmentWarnings(before, after))
+
+	report := checkReport{
+		Status:            "ok",
+		ComparisonSummary: bench.SummarizeComparison(results, cc.threshold),
+	}
+
+	code := ExitOK
+	switch {
+	case len(report.Regressions) > 0:
+		report.Status = "regression"
+		code = ExitRegression
+	case len(report.Removed) > 0 && !cc.allowMissing:
+		report.Status = "missing"
+		code = ExitMissing
+	}
+
+	cc.printReport(report)
+
+	if code != ExitOK {
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+func (cc *CheckCommand) compareError(err error) error {
+	cc.printReport(checkReport{Status: "error", ComparisonSummary: bench.SummarizeComparison(nil, cc.threshold)})
+	return &ExitError{Code: ExitInputError, Err: fmt.Errorf("error comparing benchmarks: %w", err)}
+}
+
+func (cc *CheckCommand) printReport(report checkReport) {
+	if cc.format == "json" {
+		data, _ := json.Marshal(report)
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("status=%s compared=%d regressions=%d improvements=%d added=%d removed=%d\n",
+		report.Status,
+		report.Compared,
+		len(report.Regressions),
+		len(report.Improvements),
+		len(report.Added),
+		len(report.Removed))
+
+	for _, name := range report.Regressions {
+		fmt.Printf("regression %s\n", name)
+	}
+	for _, name := range report.Removed {
+		fmt.Printf("missing %s\n", name)
+	}
+	for _, name := range report.Added {
+		fmt.Printf("added %s\n", name)
+	}
+}
+
+func (cc *CheckCommand) Usage() string {
+	return `Usage: zeno check [options] <before.json> <after.json>
+
+Compare two benchmark runs and exit with a status code suitable for CI.
+
+Prints a one-line key=value summary followed by one line per regression or
+missing benchmark. Use --format=json for a single JSON object.
+
+Exit codes:
+  0  no regressions
+  1  regressions found
+  2  benchmarks present in the baseline are missing from the new run
+  3  input error (unreadable files, bad arguments)
+
+Examples:
+  zeno check baseline.json current.json
+  zeno check --threshold=2.5 --allow-missing before.json after.json
+  zeno check --format=json old.json new.json
+  zeno check --policy=bench-policy.json baseline.json current.json
+  zeno check --history=bench.json origin/main HEAD
+  zeno check --notes origin/main HEAD
+
+Options:`
+}
@@ -1,73 +1,221 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/mateusfdl/zeno/bench"
 	flag "github.com/spf13/pflag"
 )
 
 type CompareCommand struct {
-	fs        *flag.FlagSet
-	threshold float64
-	format    string
+	fs               *flag.FlagSet
+	threshold        float64
+	compareFlags     compareFlags
+	format           string
+	pivot            string
+	history          historyFlags
+	failOnRegression bool
 }
 
 func NewCompareCommand() *CompareCommand {
 	cc := &CompareCommand{
-		fs: flag.NewFlagSet("compare", flag.ExitOnError),
+		fs: flag.NewFlagSet("compare", flag.ContinueOnError),
 	}
 
 	cc.fs.Float64VarP(&cc.threshold, "threshold", "t", 5.0, "Regression threshold percentage")
 	cc.fs.StringVarP(&cc.format, "format", "f", "table", "Output format: table or json")
+	cc.fs.StringVar(&cc.pivot, "pivot", "", "Also show time changes side by side for each value of a sub-benchmark parameter")
+	cc.history.register(cc.fs)
+	cc.compareFlags.register(cc.fs)
+	cc.fs.BoolVar(&cc.failOnRegression, "fail-on-regression", false, "Exit with status 1 when regressions are detected")
 
 	return cc
 }
 
 func (cc *CompareCommand) Run(args []string) error {
 	if err := cc.fs.Parse(args); err != nil {
-		return err
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return &ExitError{Code: ExitInputError, Err: err}
 	}
 
 	remaining := cc.fs.Args()
 	if len(remaining) != 2 {
-		return fmt.Errorf("compare requires exactly 2 file arguments (before and after)")
+		return cc.inputError(fmt.Errorf("compare requires exactly 2 arguments (before and after files, or git refs with --history)"))
+	}
+	if cc.format != "table" && cc.format != "json" {
+		return cc.inputError(fmt.Errorf("unknown format: %s (use 'table' or 'json')", cc.format))
 	}
 
 	beforePath := remaining[0]
 	afterPath := remaining[1]
 
-	results, err := bench.CompareTwoFiles(beforePath, afterPath)
+	opts, err := cc.compareFlags.options()
+	if err != nil {
+		return cc.inputError(err)
+	}
+
+	before, after, err := cc.history.runPair(beforePath, afterPath)
+	if err != nil {
+		return cc.inputError(fmt.Errorf("error comparing benchmarks: %w", err))
+	}
+
+	results, err := bench.CompareTwoRuns(before, after, opts)
 	if err != nil {
-		return fmt.Errorf("error comparing benchmarks: %w", err)
+		return cc.inputError(fmt.Errorf("error comparing benchmarks: %w", err))
 	}
+	printWarnings(bench.EnvironmentWarnings(before, after))
 
-	switch cc.format {
-	case "table":
+	if cc.format == "table" {
+		if diff := bench.FormatEnvironmentDiff(bench.DiffEnvironment(before.Environment, after.Environment)); diff != "" {
+			fmt.Println(diff)
+		}
 		output := bench.FormatComparisonResults(results, cc.threshold)
 		fmt.Println(output)
-	case "json":
+		if cc.pivot != "" {
+			fmt.Println(bench.FormatPivotTable(bench.PivotResults(results, cc.pivot)))
+		}
+	} else {
 		output := bench.FormatComparisonAsJSON(results)
 		fmt.Println(output)
-	default:
-		return fmt.Errorf("unknown format: %s (use 'table' or 'json')", cc.format)
+	}
+
+	if cc.failOnRegression {
+		summary := bench.SummarizeComparison(results, cc.threshold)
+		if len(summary.Regressions) > 0 {
+			return &ExitError{Code: ExitRegression}
+		}
 	}
 
 	return nil
 }
 
+func (cc *CompareCommand) inputError(err error) error {
+	if cc.failOnRegression {
+		return &ExitError{Code: ExitInputError, Err: err}
+	}
+	return err
+}
+
+type historyFlags struct {
+	file     string
+	notes    bool
+	notesRef string
+}
+
+func (f *historyFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.file, "history", "", "History file to look up the runs for two git refs")
+	fs.BoolVar(&f.notes, "notes", false, "Look up the runs for two git refs in git notes")
+	fs.StringVar(&f.notesRef, "notes-ref", bench.DefaultNotesRef, "Git notes ref holding benchmark runs")
+}
+
+func (f *historyFlags) runPair(before, after string) (bench.Run, bench.Run, error) {
+	if f.file != "" && f.notes {
+		return bench.Run{}, bench.Run{}, fmt.Errorf("--history and --notes are mutually exclusive")
+	}
+	if f.file == "" && !f.notes {
+		return bench.ReadRunPair(before, after)
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return bench.Run{}, bench.Run{}, err
+	}
+	if f.notes {
+		return bench.ReadNotesPair(dir, f.notesRef, before, after)
+	}
+	return bench.ReadHistoryPair(f.file, dir, before, after)
+}
+
+func printWarnings(warnings []string) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+}
+
+type compareFlags struct {
+	alpha      float64
+	policy     string
+	matchProcs bool
+	filters    []string
+}
+
+func (f *compareFlags) register(fs *flag.FlagSet) {
+	fs.Float64Var(&f.alpha, "alpha", 0.05, "Significance level for the Mann-Whitney U test")
+	fs.StringVarP(&f.policy, "policy", "p", "", "Performance budget policy file (JSON)")
+	fs.BoolVar(&f.matchProcs, "match-procs", false, "Only match benchmarks with the same GOMAXPROCS suffix")
+	fs.StringArrayVar(&f.filters, "filter", nil, "Only compare sub-benchmarks with parameter key=value (repeatable)")
+}
+
+func (f *compareFlags) options() (bench.CompareOptions, error) {
+	opts := bench.DefaultCompareOptions()
+	if f.alpha <= 0 || f.alpha >= 1 {
+		return opts, fmt.Errorf("--alpha must be between 0 and 1, got %g", f.alpha)
+	}
+	opts.Alpha = f.alpha
+	opts.MatchProcs = f.matchProcs
+
+	params, err := bench.ParseParamFilters(f.filters)
+	if err != nil {
+		return opts, err
+	}
+	opts.Params = params
+
+	if f.policy != "" {
+		policy, err := bench.LoadPolicy(f.policy)
+		if err != nil {
+			return opts, err
+		}
+		opts.Policy = policy
+	}
+
+	return opts, nil
+}
+
 func (cc *CompareCommand) Usage() string {
 	return `Usage: zeno compare [options] <before.json> <after.json>
 
 Compare two benchmark runs and detect performance regressions.
 
 Compares benchmark metrics between two runs and calculates percentage changes.
-Reports regressions exceeding the threshold.
+When both runs have at least two samples per benchmark (go test -count=N), a
+Mann-Whitney U test is applied and changes that are not statistically
+significant are shown as "~". Reports significant regressions exceeding the
+threshold.
+
+Benchmarks are matched by name without the -N GOMAXPROCS suffix, so runs from
+machines with different core counts line up. When a benchmark was run with
+several -cpu values, or with --match-procs, the suffix is part of the match.
+
+Sub-benchmark names are split on "/" and key=value segments become
+parameters: BenchmarkSort/algo=quick/size=1000 has algo=quick and size=1000.
+--filter keeps only matching sub-benchmarks and --pivot lays out the time
+change for each value of one parameter side by side.
+
+With --history, the two arguments are git refs instead of files. Each ref is
+resolved with git rev-parse and matched against the commit recorded by
+zeno parse (or a --version holding the commit SHA); the latest matching run
+in the history file is used. --notes reads the runs stored by
+zeno parse --notes from git notes instead.
+
+When both runs were parsed with --env, the table output starts with the
+environment fields that differ, and a warning is printed when the hardware
+(CPU model, core count or memory) changed.
 
 Examples:
   zeno compare baseline.json current.json
   zeno compare --threshold=2.5 before.json after.json
+  zeno compare --alpha=0.01 before.json after.json
+  zeno compare --fail-on-regression baseline.json current.json
+  zeno compare --policy=bench-policy.json before.json after.json
+  zeno compare --match-procs laptop.json ci.json
+  zeno compare --filter algo=quick --pivot size before.json after.json
   zeno compare --format=json old.json new.json
+  zeno compare --history=bench.json main HEAD
+  zeno compare --notes main HEAD
 
 Options:`
 }
@@ -0,0 +1,26 @@
+package cmd
+
+import "fmt"
+
+const (
+	ExitOK         = 0
+	ExitRegression = 1
+	ExitMissing    = 2
+	ExitInputError = 3
+)
+
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
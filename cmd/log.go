@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mateusfdl/zeno/bench"
+	flag "github.com/spf13/pflag"
+)
+
+type LogCommand struct {
+	fs       *flag.FlagSet
+	notesRef string
+	limit    int
+	format   string
+}
+
+func NewLogCommand() *LogCommand {
+	lc := &LogCommand{
+		fs: flag.NewFlagSet("log", flag.ExitOnError),
+	}
+
+	lc.fs.StringVar(&lc.notesRef, "notes-ref", bench.DefaultNotesRef, "Git notes ref holding benchmark runs")
+	lc.fs.IntVarP(&lc.limit, "max-count", "n", 0, "Only walk this many commits (default: all)")
+	lc.fs.StringVarP(&lc.format, "format", "f", "text", "Output format: text or json")
+
+	return lc
+}
+
+func (lc *LogCommand) Run(args []string) error {
+	if err := lc.fs.Parse(args); err != nil {
+		return err
+	}
+
+	remaining := lc.fs.Args()
+	if len(remaining) > 1 {
+		return fmt.Errorf("log takes at most one revision")
+	}
+	revision := "HEAD"
+	if len(remaining) == 1 {
+		revision = remaining[0]
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	runs, err := bench.ReadNotesHistory(dir, lc.notesRef, revision, lc.limit)
+	if err != nil {
+		return fmt.Errorf("error reading history: %w", err)
+	}
+
+	switch lc.format {
+	case "text":
+		if len(runs) == 0 {
+			fmt.Fprintf(os.Stderr, "No runs found in %s\n", lc.notesRef)
+			return nil
+		}
+		for i := len(runs) - 1; i >= 0; i-- {
+			fmt.Println(formatLogLine(runs[i]))
+		}
+	case "json":
+		return bench.EncodeRuns(os.Stdout, runs)
+	default:
+		return fmt.Errorf("unknown format: %s (use 'text' or 'json')", lc.format)
+	}
+
+	return nil
+}
+
+func formatLogLine(run bench.Run) string {
+	var parts []string
+
+	commit := run.Git.ShortCommit()
+	if run.Git.Dirty {
+		commit += "+"
+	}
+	parts = append(parts, commit)
+
+	date := "-"
+	if run.Date > 0 {
+		date = time.Unix(run.Date, 0).Format("2006-01-02 15:04")
+	}
+	parts = append(parts, date)
+
+	benchmarks := 0
+	for _, s := range run.Suites {
+		benchmarks += len(s.Benchmarks)
+	}
+	parts = append(parts, fmt.Sprintf("%d suites, %d benchmarks", len(run.Suites), benchmarks))
+
+	if elapsed := bench.FormatElapsed(run.Elapsed()); elapsed != "" {
+		parts = append(parts, elapsed)
+	}
+	if run.Version != "" {
+		parts = append(parts, run.Version)
+	}
+	if run.Git.Branch != "" {
+		parts = append(parts, "("+run.Git.Branch+")")
+	}
+	if run.Git.Subject != "" {
+		parts = append(parts, run.Git.Subject)
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+func (lc *LogCommand) Usage() string {
+	return `Usage: zeno log [options] [revision]
+
+List the benchmark runs stored in git notes.
+
+Walks git log from revision (default: HEAD) and prints every run stored with
+zeno parse --notes, newest first. A "+" after the commit marks runs recorded
+from a dirty work tree. Use --format=json to get the runs as a history file,
+oldest first.
+
+Examples:
+  zeno log
+  zeno log -n 20 main
+  zeno log --format=json | zeno view --web
+
+Options:`
+}
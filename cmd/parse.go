@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/mateusfdl/zeno/bench"
@@ -10,12 +12,9 @@ import (
 )
 
 type ParseCommand struct {
-	fs      *flag.FlagSet
-	output  string
-	version string
-	tags    []string
-	append  bool
-	date    int64
+	fs          *flag.FlagSet
+	recordFlags recordFlags
+	parseFlags  parseFlags
 }
 
 func NewParseCommand() *ParseCommand {
@@ -23,11 +22,9 @@ func NewParseCommand() *ParseCommand {
 		fs: flag.NewFlagSet("parse", flag.ExitOnError),
 	}
 
-	pc.fs.StringVarP(&pc.output, "output", "o", "", "Output file path (default: stdout)")
-	pc.fs.StringVar(&pc.version, "version", "", "Version tag for this run")
-	pc.fs.StringSliceVar(&pc.tags, "tags", []string{}, "Tags to add to this run")
-	pc.fs.BoolVar(&pc.append, "append", false, "Append to existing output file")
-	pc.fs.Int64Var(&pc.date, "date", time.Now().Unix(), "Timestamp for this run (Unix timestamp)")
+	pc.recordFlags.register(pc.fs)
+	pc.parseFlags.register(pc.fs)
+	pc.fs.BoolVar(&pc.parseFlags.store, "store-diagnostics", false, "Store parse diagnostics in the run")
 
 	return pc
 }
@@ -36,26 +33,143 @@ func (pc *ParseCommand) Run(args []string) error {
 	if err := pc.fs.Parse(args); err != nil {
 		return err
 	}
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeNamedPipe != 0 && pc.recordFlags.checkingEnv() {
+		fmt.Fprintln(os.Stderr, "Warning: --env-check runs after the piped go test has started measuring; use zeno run to check before benchmarks start")
+	}
+	if err := pc.recordFlags.preflight(); err != nil {
+		return err
+	}
+
+	p, err := pc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
 
-	p := bench.NewParser()
 	suites, err := p.ParseStdin()
 	if err != nil {
 		return fmt.Errorf("error parsing benchmark: %w", err)
 	}
+	printDiagnostics(p.Diagnostics())
 
 	if len(suites) == 0 {
 		return fmt.Errorf("no benchmark suites found")
 	}
 
-	run := bench.CreateRun(suites, pc.version, pc.date, pc.tags)
+	run := bench.CreateRun(suites, pc.recordFlags.version, pc.recordFlags.date, pc.recordFlags.tags)
+	if pc.parseFlags.store {
+		run.Diagnostics = p.Diagnostics()
+	}
 
-	if pc.output != "" {
-		if err := bench.WriteRunToFile(pc.output, &run, pc.append); err != nil {
+	return pc.recordFlags.record(&run)
+}
+
+type recordFlags struct {
+	output   string
+	version  string
+	tags     []string
+	append   bool
+	date     int64
+	env      bool
+	noGit    bool
+	notes    bool
+	notesRef string
+	envCheck bool
+	envMode  string
+	policy   string
+
+	stability *bench.Stability
+}
+
+func (f *recordFlags) register(fs *flag.FlagSet) {
+	fs.StringVarP(&f.output, "output", "o", "", "Output file path (default: stdout)")
+	fs.StringVar(&f.version, "version", "", "Version tag for this run")
+	fs.StringSliceVar(&f.tags, "tags", []string{}, "Tags to add to this run")
+	fs.BoolVar(&f.append, "append", false, "Append to existing output file")
+	fs.Int64Var(&f.date, "date", time.Now().Unix(), "Timestamp for this run (Unix timestamp)")
+	fs.BoolVar(&f.env, "env", false, "Record the host environment (CPU, memory, kernel, load) on the run")
+	fs.BoolVar(&f.noGit, "no-git", false, "Do not record git commit metadata on the run")
+	fs.BoolVar(&f.notes, "notes", false, "Store the run as a git note on the benchmarked commit")
+	fs.StringVar(&f.notesRef, "notes-ref", bench.DefaultNotesRef, "Git notes ref used by --notes")
+	fs.BoolVar(&f.envCheck, "env-check", false, "Check that the machine is quiet before measuring")
+	fs.StringVar(&f.envMode, "env-check-mode", "", "What --env-check does with problems: warn (default) or refuse; implies --env-check")
+	fs.StringVar(&f.policy, "env-policy", "", "Policy file whose environment section sets the --env-check limits")
+}
+
+func (f *recordFlags) checkingEnv() bool {
+	return f.envCheck || f.envMode != ""
+}
+
+func (f *recordFlags) preflight() error {
+	if !f.checkingEnv() {
+		return nil
+	}
+	if f.envMode != "" && f.envMode != bench.StabilityWarn && f.envMode != bench.StabilityRefuse {
+		return fmt.Errorf("unknown --env-check-mode: %s (use 'warn' or 'refuse')", f.envMode)
+	}
+
+	policy := bench.DefaultStabilityPolicy()
+	if f.policy != "" {
+		p, err := bench.LoadPolicy(f.policy)
+		if err != nil {
+			return err
+		}
+		if p.Environment != nil {
+			policy = *p.Environment
+		}
+	}
+
+	f.stability = bench.CheckStability(policy)
+	if f.stability.Stable() {
+		return nil
+	}
+	if f.envMode == bench.StabilityRefuse {
+		return fmt.Errorf("unstable benchmark environment: %s", strings.Join(f.stability.Issues, ", "))
+	}
+	for _, issue := range f.stability.Issues {
+		fmt.Fprintf(os.Stderr, "Warning: unstable benchmark environment: %s\n", issue)
+	}
+	return nil
+}
+
+func (f *recordFlags) toStdout() bool {
+	return f.output == "" && !f.notes
+}
+
+func (f *recordFlags) record(run *bench.Run) error {
+	if f.env {
+		run.Environment = bench.CaptureEnvironment()
+	}
+	run.Stability = f.stability
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if !f.noGit || f.notes {
+		git, err := bench.CaptureGit(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		run.Git = git
+	}
+
+	if f.notes {
+		if run.Git == nil {
+			return fmt.Errorf("--notes requires a git work tree with at least one commit")
+		}
+		if err := bench.WriteRunNote(dir, f.notesRef, run.Git.Commit, run); err != nil {
+			return fmt.Errorf("error writing note: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Stored run as note on %s (%s)\n", run.Git.ShortCommit(), f.notesRef)
+	}
+
+	if f.output != "" {
+		if err := bench.WriteRunToFile(f.output, run, f.append); err != nil {
 			return fmt.Errorf("error writing output: %w", err)
 		}
-		fmt.Fprintf(os.Stderr, "Parsed %d benchmark suites to %s\n", len(suites), pc.output)
-	} else {
-		if err := bench.EncodeRuns(os.Stdout, []bench.Run{run}); err != nil {
+		fmt.Fprintf(os.Stderr, "Parsed %d benchmark suites to %s\n", len(run.Suites), f.output)
+	} else if f.toStdout() {
+		if err := bench.EncodeRuns(os.Stdout, []bench.Run{*run}); err != nil {
 			return fmt.Errorf("error encoding output: %w", err)
 		}
 	}
@@ -63,16 +177,103 @@ func (pc *ParseCommand) Run(args []string) error {
 	return nil
 }
 
+type parseFlags struct {
+	strict    bool
+	lenient   bool
+	store     bool
+	goVersion string
+}
+
+func (f *parseFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.strict, "strict", false, "Fail on the first malformed benchmark line")
+	fs.BoolVar(&f.lenient, "lenient", false, "Skip malformed benchmark lines and report them as warnings (default)")
+	fs.StringVar(&f.goVersion, "go-version", "", "Go version of the toolchain (default: $GOVERSION or go env GOVERSION)")
+}
+
+func (f *parseFlags) parser() (*bench.Parser, error) {
+	if f.strict && f.lenient {
+		return nil, fmt.Errorf("--strict and --lenient are mutually exclusive")
+	}
+
+	p := bench.NewParser()
+	p.SetStrict(f.strict)
+	p.SetGoVersion(f.detectGoVersion())
+
+	if dir, err := os.Getwd(); err == nil {
+		module, err := bench.FindModulePath(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		p.SetModulePath(module)
+	}
+	return p, nil
+}
+
+func (f *parseFlags) detectGoVersion() string {
+	if f.goVersion != "" {
+		return f.goVersion
+	}
+	if v := os.Getenv("GOVERSION"); v != "" {
+		return v
+	}
+
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func printDiagnostics(diagnostics []bench.Diagnostic) {
+	for _, d := range diagnostics {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", d.Error())
+	}
+}
+
 func (pc *ParseCommand) Usage() string {
 	return `Usage: zeno parse [options]
 
 Parse benchmark output from stdin and output structured JSON.
 
 Reads Go benchmark output from stdin and produces JSON format.
-Can write to a file or stdout.
+Can write to a file or stdout. Output from go test -json is detected
+automatically; package pass/fail status and elapsed time are kept.
+
+The Go version is taken from --go-version, $GOVERSION or go env GOVERSION.
+Package paths are also stored relative to the module in the nearest go.mod.
+
+Inside a git work tree the commit SHA, branch, dirty state, commit time and
+subject are recorded on the run; --no-git turns this off.
+
+--notes stores the run as a git note on the benchmarked commit under
+refs/notes/zeno (see --notes-ref) instead of printing it. Share the notes with
+git push origin refs/notes/zeno and git fetch origin refs/notes/zeno:refs/notes/zeno.
+
+--env records the host environment on the run: hostname, kernel, CPU model
+and core count, total memory, GOMAXPROCS, CPU frequency governor and load
+average. compare shows the differences between two runs.
+
+--env-check inspects /proc and /sys: the CPU frequency governor, turbo boost,
+the load average per online CPU, offline CPUs, and available memory and memory
+pressure. Problems are printed as warnings, or abort the command with
+--env-check-mode=refuse. The findings are stored on the run and compare warns
+about runs recorded in an unstable environment. Limits come from the
+environment section of --env-policy. With piped input, go test is already
+measuring when the check runs and the load average includes the benchmarks
+themselves; use zeno run --env-check to check before go test starts.
+
+Malformed benchmark lines are skipped and reported as warnings with their line
+number in the input; for go test -json input that is the line of the event
+that started the benchmark line. Use --strict to fail instead, and --store-diagnostics to keep the
+warnings in the stored run.
 
 Examples:
   go test -bench=. -benchmem | ueno parse -o results.json
   go test -bench=. | zeno parse --version=v1.0.0 --tags=ci
+  go test -json -bench=. ./... | zeno parse -o results.json
+  go test -bench=. | zeno parse --strict -o results.json
+  go test -bench=. | zeno parse --go-version=go1.23.4
+  go test -bench=. | zeno parse --env -o results.json
+  go test -bench=. | zeno parse --notes
   zeno parse --append -o history.json`
 }
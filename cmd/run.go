@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/mateusfdl/zeno/bench"
+	flag "github.com/spf13/pflag"
+)
+
+type RunCommand struct {
+	fs          *flag.FlagSet
+	recordFlags recordFlags
+	parseFlags  parseFlags
+	testFlags   testFlags
+}
+
+func NewRunCommand() *RunCommand {
+	rc := &RunCommand{
+		fs: flag.NewFlagSet("run", flag.ExitOnError),
+	}
+
+	rc.testFlags.register(rc.fs)
+	rc.recordFlags.register(rc.fs)
+	rc.parseFlags.register(rc.fs)
+	rc.fs.BoolVar(&rc.parseFlags.store, "store-diagnostics", false, "Store parse diagnostics in the run")
+
+	return rc
+}
+
+func (rc *RunCommand) Run(args []string) error {
+	if err := rc.fs.Parse(args); err != nil {
+		return err
+	}
+	if err := rc.recordFlags.preflight(); err != nil {
+		return err
+	}
+
+	p, err := rc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
+
+	live := io.Writer(os.Stdout)
+	if rc.recordFlags.toStdout() {
+		live = os.Stderr
+	}
+
+	cmd := exec.Command("go", rc.testFlags.args(rc.fs)...)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting go test: %w", err)
+	}
+
+	output := io.TeeReader(stdout, live)
+	suites, parseErr := p.Parse(output)
+	if parseErr != nil {
+		io.Copy(live, stdout)
+	}
+
+	code := 0
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("error running go test: %w", err)
+		}
+		code = exitErr.ExitCode()
+	}
+
+	if parseErr != nil {
+		return fmt.Errorf("error parsing benchmark: %w", parseErr)
+	}
+	printDiagnostics(p.Diagnostics())
+
+	if len(suites) == 0 {
+		if code != 0 {
+			return &ExitError{Code: code}
+		}
+		return fmt.Errorf("no benchmark suites found")
+	}
+
+	run := bench.CreateRun(suites, rc.recordFlags.version, rc.recordFlags.date, rc.recordFlags.tags)
+	if rc.parseFlags.store {
+		run.Diagnostics = p.Diagnostics()
+	}
+	if err := rc.recordFlags.record(&run); err != nil {
+		return err
+	}
+
+	if code != 0 {
+		return &ExitError{Code: code}
+	}
+	return nil
+}
+
+type testFlags struct {
+	bench     string
+	run       string
+	count     int
+	cpu       string
+	benchtime string
+	benchmem  bool
+}
+
+func (f *testFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.bench, "bench", ".", "Run only benchmarks matching this regexp (go test -bench)")
+	fs.StringVar(&f.run, "run", "^$", "Run only tests matching this regexp (go test -run)")
+	fs.IntVar(&f.count, "count", 0, "Run each benchmark n times (go test -count)")
+	fs.StringVar(&f.cpu, "cpu", "", "GOMAXPROCS values to run with (go test -cpu)")
+	fs.StringVar(&f.benchtime, "benchtime", "", "Benchmark time or iterations (go test -benchtime)")
+	fs.BoolVar(&f.benchmem, "benchmem", true, "Report memory allocations (go test -benchmem)")
+}
+
+func (f *testFlags) args(fs *flag.FlagSet) []string {
+	args := []string{"test", "-run", f.run, "-bench", f.bench}
+	if f.benchmem {
+		args = append(args, "-benchmem")
+	}
+	if f.count > 0 {
+		args = append(args, "-count", strconv.Itoa(f.count))
+	}
+	if f.cpu != "" {
+		args = append(args, "-cpu", f.cpu)
+	}
+	if f.benchtime != "" {
+		args = append(args, "-benchtime", f.benchtime)
+	}
+
+	packages, extra := f.packages(fs)
+	args = append(args, packages...)
+	return append(args, extra...)
+}
+
+func (f *testFlags) packages(fs *flag.FlagSet) ([]string, []string) {
+	packages := fs.Args()
+	var extra []string
+	if dash := fs.ArgsLenAtDash(); dash >= 0 {
+		packages, extra = packages[:dash], packages[dash:]
+	}
+	if len(packages) == 0 {
+		packages = []string{"./..."}
+	}
+	return packages, extra
+}
+
+func (rc *RunCommand) Usage() string {
+	return `Usage: zeno run [options] [packages] [-- go test flags]
+
+Run go test benchmarks and record the results in one step.
+
+Runs go test -run '^$' -bench . -benchmem on the given packages (default:
+./...), streams its output live and parses it as it arrives. The run is then
+recorded like zeno parse does: written or appended to --output, stored as a
+git note with --notes, or printed as JSON. When the JSON goes to stdout, the
+go test output is streamed to stderr instead.
+
+go test's stderr and environment are passed through, and zeno exits with go
+test's exit status. Results are still recorded when benchmarks fail.
+With --env-check the machine is checked before go test starts (see zeno parse
+--help).
+Arguments after -- are passed to go test unchanged.
+
+Examples:
+  zeno run -o bench.json --append
+  zeno run --bench=Encode --count=10 ./encoding/...
+  zeno run --cpu=1,4,8 --benchtime=2s --notes
+  zeno run --env-check-mode=refuse --env-policy=bench-policy.json
+  zeno run ./... -- -tags=integration -timeout=30m
+
+Options:`
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,12 +16,17 @@ import (
 )
 
 type ViewCommand struct {
-	fs        *flag.FlagSet
-	filePath  string
-	compare   string
-	threshold float64
-	web       bool
-	webOutput string
+	fs           *flag.FlagSet
+	filePath     string
+	compare      string
+	threshold    float64
+	compareFlags compareFlags
+	parseFlags   parseFlags
+	web          bool
+	webOutput    string
+	notes        bool
+	notesRef     string
+	limit        int
 }
 
 func NewViewCommand() *ViewCommand {
@@ -31,6 +37,11 @@ func NewViewCommand() *ViewCommand {
 	vc.fs.StringVarP(&vc.filePath, "file", "f", "", "JSON file to view (default: stdin)")
 	vc.fs.StringVarP(&vc.compare, "compare", "c", "", "Compare with this file (enables comparison mode)")
 	vc.fs.Float64VarP(&vc.threshold, "threshold", "t", 5.0, "Regression threshold percentage")
+	vc.compareFlags.register(vc.fs)
+	vc.parseFlags.register(vc.fs)
+	vc.fs.BoolVar(&vc.notes, "notes", false, "View the runs stored in git notes, oldest first")
+	vc.fs.StringVar(&vc.notesRef, "notes-ref", bench.DefaultNotesRef, "Git notes ref holding benchmark runs")
+	vc.fs.IntVarP(&vc.limit, "max-count", "n", 0, "With --notes, only walk this many commits")
 	vc.fs.BoolVarP(&vc.web, "web", "w", false, "Generate HTML report instead of TUI")
 	vc.fs.StringVarP(&vc.webOutput, "output", "o", "bench-report.html", "Output file for HTML report")
 
@@ -42,6 +53,10 @@ func (vc *ViewCommand) Run(args []string) error {
 		return err
 	}
 
+	if vc.notes {
+		return vc.runNotes()
+	}
+
 	if vc.web {
 		if vc.compare != "" {
 			return vc.runWebComparison()
@@ -62,7 +77,12 @@ func (vc *ViewCommand) Run(args []string) error {
 }
 
 func (vc *ViewCommand) runComparison() error {
-	results, err := bench.CompareTwoFiles(vc.filePath, vc.compare)
+	opts, err := vc.compareFlags.options()
+	if err != nil {
+		return err
+	}
+
+	results, err := bench.CompareTwoFiles(vc.filePath, vc.compare, opts)
 	if err != nil {
 		return fmt.Errorf("error comparing files: %w", err)
 	}
@@ -75,6 +95,11 @@ func (vc *ViewCommand) runComparison() error {
 }
 
 func (vc *ViewCommand) runSingleFile() error {
+	opts, err := vc.compareFlags.options()
+	if err != nil {
+		return err
+	}
+
 	runs, err := bench.ReadRuns(vc.filePath)
 	if err != nil {
 		return fmt.Errorf("error reading file: %w", err)
@@ -84,7 +109,40 @@ func (vc *ViewCommand) runSingleFile() error {
 		return fmt.Errorf("no benchmark runs found in file")
 	}
 
-	model := tui.NewModel(runs, vc.threshold)
+	model := tui.NewModel(runs, vc.threshold).WithCompareOptions(opts)
+	p := runTea(model)
+
+	_, err = p.Run()
+	return err
+}
+
+func (vc *ViewCommand) runNotes() error {
+	opts, err := vc.compareFlags.options()
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	runs, err := bench.ReadNotesHistory(dir, vc.notesRef, "HEAD", vc.limit)
+	if err != nil {
+		return fmt.Errorf("error reading history: %w", err)
+	}
+
+	if len(runs) == 0 {
+		return fmt.Errorf("no benchmark runs found in %s", vc.notesRef)
+	}
+
+	if vc.web {
+		generator := web.NewGenerator(runs, vc.threshold)
+		fmt.Printf("Generating HTML report: %s\n", vc.webOutput)
+		return generator.GenerateToFileAndOpen(vc.webOutput)
+	}
+
+	model := tui.NewModel(runs, vc.threshold).WithCompareOptions(opts)
 	p := runTea(model)
 
 	_, err = p.Run()
@@ -105,17 +163,25 @@ func (vc *ViewCommand) runStdin() error {
 
 	runs, err := bench.DecodeRuns(strings.NewReader(string(data)))
 	if err == nil && len(runs) > 0 {
-		model := tui.NewModel(runs, vc.threshold)
+		opts, err := vc.compareFlags.options()
+		if err != nil {
+			return err
+		}
+		model := tui.NewModel(runs, vc.threshold).WithCompareOptions(opts)
 		p := runTea(model)
 		_, err = p.Run()
 		return err
 	}
 
-	parser := bench.NewParser()
+	parser, err := vc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
 	suites, err := parser.ParseBytes(data)
 	if err != nil {
 		return fmt.Errorf("error parsing benchmark output: %w", err)
 	}
+	printDiagnostics(parser.Diagnostics())
 
 	run := bench.CreateRun(suites, "", 0, nil)
 	model := tui.NewModel([]bench.Run{run}, vc.threshold)
@@ -126,19 +192,55 @@ func (vc *ViewCommand) runStdin() error {
 }
 
 func (vc *ViewCommand) runStreamingStdin() error {
-	model := tui.NewStreamingModel(vc.threshold)
+	parser, err := vc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
+
+	model := tui.NewStreamingModel(parser, vc.threshold)
 	p := runTea(model)
 
 	go func() {
-		scanner := bufio.NewScanner(os.Stdin)
-		for scanner.Scan() {
-			p.Send(tui.BenchmarkLineMsg{Line: scanner.Text()})
-		}
-		p.Send(tui.StreamDoneMsg{Err: scanner.Err()})
+		p.Send(tui.StreamDoneMsg{Err: sendLines(p, os.Stdin)})
 	}()
 
-	_, err := p.Run()
-	return err
+	final, err := p.Run()
+	if err != nil {
+		return err
+	}
+	printDiagnostics(parser.Diagnostics())
+
+	if err := final.(tui.Model).Err(); err != nil {
+		return fmt.Errorf("error parsing benchmark output: %w", err)
+	}
+	return nil
+}
+
+func sendLines(p *tea.Program, r io.Reader) error {
+	stream := bench.NewTestJSONStream()
+	br := bufio.NewReader(r)
+	for {
+		text, err := br.ReadString('\n')
+		if text != "" {
+			text = strings.TrimRight(text, "\r\n")
+			lines, ok := stream.Lines(text)
+			if !ok {
+				lines = []string{text}
+			}
+			for _, line := range lines {
+				p.Send(tui.BenchmarkLineMsg{Line: line})
+			}
+		}
+		if err != nil {
+			for _, line := range stream.Flush() {
+				p.Send(tui.BenchmarkLineMsg{Line: line})
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
 }
 
 func runTea(model tui.Model) *tea.Program {
@@ -146,7 +248,12 @@ func runTea(model tui.Model) *tea.Program {
 }
 
 func (vc *ViewCommand) runWebComparison() error {
-	results, err := bench.CompareTwoFiles(vc.filePath, vc.compare)
+	opts, err := vc.compareFlags.options()
+	if err != nil {
+		return err
+	}
+
+	results, err := bench.CompareTwoFiles(vc.filePath, vc.compare, opts)
 	if err != nil {
 		return fmt.Errorf("error comparing files: %w", err)
 	}
@@ -184,11 +291,15 @@ func (vc *ViewCommand) runWebStdin() error {
 		return generator.GenerateToFileAndOpen(vc.webOutput)
 	}
 
-	parser := bench.NewParser()
+	parser, err := vc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
 	suites, err := parser.ParseBytes(data)
 	if err != nil {
 		return fmt.Errorf("error parsing benchmark output: %w", err)
 	}
+	printDiagnostics(parser.Diagnostics())
 
 	run := bench.CreateRun(suites, "", 0, nil)
 	generator := web.NewGenerator([]bench.Run{run}, vc.threshold)
@@ -220,6 +331,15 @@ Examples:
   # Pipe from go test to HTML
   go test -bench=. -benchmem | zeno view --web
 
+  # go test -json output is detected automatically
+  go test -json -bench=. ./... | zeno view
+
+  # View the history stored in git notes
+  zeno view --notes --web
+
+  # Browse a merged history; press r for the run list
+  zeno view -f history.json
+
   # Save and view
   go test -bench=. | zeno parse | zeno view --web
 
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	tui "github.com/mateusfdl/zeno/views/terminal"
+	flag "github.com/spf13/pflag"
+)
+
+type WatchCommand struct {
+	fs           *flag.FlagSet
+	testFlags    testFlags
+	parseFlags   parseFlags
+	compareFlags compareFlags
+	threshold    float64
+	interval     time.Duration
+}
+
+func NewWatchCommand() *WatchCommand {
+	wc := &WatchCommand{
+		fs: flag.NewFlagSet("watch", flag.ExitOnError),
+	}
+
+	wc.testFlags.register(wc.fs)
+	wc.fs.DurationVar(&wc.interval, "interval", time.Second, "How often to poll the package sources for changes")
+	wc.fs.Float64VarP(&wc.threshold, "threshold", "t", 5.0, "Regression threshold percentage")
+	wc.compareFlags.register(wc.fs)
+	wc.parseFlags.register(wc.fs)
+
+	return wc
+}
+
+func (wc *WatchCommand) Run(args []string) error {
+	if err := wc.fs.Parse(args); err != nil {
+		return err
+	}
+	if wc.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	opts, err := wc.compareFlags.options()
+	if err != nil {
+		return err
+	}
+	parser, err := wc.parseFlags.parser()
+	if err != nil {
+		return err
+	}
+
+	model := tui.NewWatchModel(parser, wc.threshold, opts)
+	p := runTea(model)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wc.watch(ctx, p)
+	}()
+
+	final, err := p.Run()
+	cancel()
+	<-done
+	if err != nil {
+		return err
+	}
+	printDiagnostics(parser.Diagnostics())
+
+	if err := final.(tui.Model).Err(); err != nil {
+		return fmt.Errorf("error parsing benchmark output: %w", err)
+	}
+	return nil
+}
+
+func (wc *WatchCommand) watch(ctx context.Context, p *tea.Program) {
+	packages, _ := wc.testFlags.packages(wc.fs)
+	ticker := time.NewTicker(wc.interval)
+	defer ticker.Stop()
+
+	for {
+		dirs := sourceDirs(ctx, packages)
+		fingerprint := sourceFingerprint(dirs)
+
+		p.Send(tui.IterationStartMsg{})
+		err := wc.iterate(ctx, p)
+		if ctx.Err() != nil {
+			return
+		}
+		p.Send(tui.StreamDoneMsg{Err: err})
+
+		for sourceFingerprint(dirs) == fingerprint {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+func (wc *WatchCommand) iterate(ctx context.Context, p *tea.Program) error {
+	cmd := exec.CommandContext(ctx, "go", wc.testFlags.args(wc.fs)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting go test: %w", err)
+	}
+
+	readErr := sendLines(p, stdout)
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("error running go test: %w", err)
+		}
+		if msg := lastLines(stderr.String(), 10); msg != "" {
+			return fmt.Errorf("go test: %s\n%s", exitErr, msg)
+		}
+		return fmt.Errorf("go test: %s", exitErr)
+	}
+	return readErr
+}
+
+func sourceDirs(ctx context.Context, packages []string) []string {
+	args := append([]string{"list", "-e", "-deps", "-test", "-f",
+		"{{if .Module}}{{if .Module.Main}}{{.Dir}}{{end}}{{end}}"}, packages...)
+	out, err := exec.CommandContext(ctx, "go", args...).Output()
+	if err != nil {
+		return []string{"."}
+	}
+
+	var dirs []string
+	for _, dir := range strings.Fields(string(out)) {
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func sourceFingerprint(dirs []string) string {
+	var sb strings.Builder
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&sb, "%s %d %d\n", filepath.Join(dir, e.Name()), info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return sb.String()
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (wc *WatchCommand) Usage() string {
+	return `Usage: zeno watch [options] [packages] [-- go test flags]
+
+Rerun benchmarks whenever the package sources change.
+
+Runs go test -run '^$' -bench . -benchmem on the given packages (default:
+./...) and streams the results into the TUI, like zeno view does for piped
+output. The .go files of the packages, and of the packages they import from
+the same module, are polled every --interval; each change starts a new
+iteration.
+
+The first tab shows every benchmark of the current iteration with its change
+against the previous iteration and against the first (baseline) iteration.
+Changes that are not statistically significant are shown as ~. Build errors
+and failing benchmarks are shown in place and the next change retries.
+
+Examples:
+  zeno watch
+  zeno watch --bench=Encode ./encoding
+  zeno watch --count=5 --benchtime=200ms --interval=500ms
+  zeno watch ./... -- -tags=integration
+
+Options:`
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -23,12 +24,22 @@ func main() {
 	switch command {
 	case "parse":
 		commander = cmd.NewParseCommand()
+	case "run":
+		commander = cmd.NewRunCommand()
 	case "merge":
 		commander = cmd.NewMergeCommand()
 	case "compare":
 		commander = cmd.NewCompareCommand()
+	case "check":
+		commander = cmd.NewCheckCommand()
 	case "view":
 		commander = cmd.NewViewCommand()
+	case "log":
+		commander = cmd.NewLogCommand()
+	case "ab":
+		commander = cmd.NewABCommand()
+	case "watch":
+		commander = cmd.NewWatchCommand()
 	case "version", "--version", "-v":
 		fmt.Printf("Zeno version %s\n", version)
 		os.Exit(0)
@@ -42,6 +53,13 @@ func main() {
 	}
 
 	if err := commander.Run(args); err != nil {
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", exitErr.Err)
+			}
+			os.Exit(exitErr.Code)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -57,9 +75,14 @@ USAGE:
 
 COMMANDS:
     parse      Parse benchmark output from stdin and output JSON
+    run        Run go test benchmarks and record the results
     merge      Merge multiple benchmark JSON files
     compare    Compare two benchmark runs and detect regressions
+    check      Compare two runs and exit non-zero on regressions (for CI)
+    ab         Benchmark two git refs interleaved and compare them
     view       View benchmark results (TUI or HTML web report)
+    watch      Rerun benchmarks on source changes and show the deltas live
+    log        List benchmark runs stored in git notes
     version    Show version information
     help       Show this help message
 
@@ -70,15 +93,28 @@ EXAMPLES:
     # Parse with metadata
     go test -bench=. | zeno parse --version=v1.0.0 --tags=ci -o results.json
 
+    # Run benchmarks and append them to a history file
+    zeno run --count=5 -o history.json --append
+
     # Merge benchmark files
     zeno merge -o combined.json file1.json file2.json file3.json
 
     # Compare benchmarks
     zeno compare baseline.json current.json
 
+    # Benchmark two git refs interleaved
+    zeno ab --rounds=10 main HEAD
+
     # Compare with custom threshold
     zeno compare --threshold=2.5 before.json after.json
 
+    # Gate a CI job on regressions
+    zeno check baseline.json current.json
+
+    # Store runs in git notes and compare two commits
+    go test -bench=. | zeno parse --notes
+    zeno compare --notes main HEAD
+
     # View in TUI
     zeno view -f results.json
 
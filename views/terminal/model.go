@@ -2,8 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,6 +22,8 @@ type StreamDoneMsg struct {
 	Err error
 }
 
+type IterationStartMsg struct{}
+
 type SortMode int
 
 const (
@@ -38,24 +43,40 @@ type Model struct {
 	quitting     bool
 	showHelp     bool
 	selectedRuns []int
+	currentRun   int
+	trendIndex   int
+	trends       []bench.BenchmarkTrend
+	runCursor    int
+	showRuns     bool
+	comparedRuns bool
 	currentTab   int
 	sortMode     SortMode
+	pivotParam   string
 	viewport     viewport.Model
 	ready        bool
 	streaming    bool
-	parser       *bench.StreamingParser
+	parser       *bench.Parser
+	collector    *bench.Collector
+	err          error
+	watching     bool
+	iteration    int
+	baseline     *bench.Run
+	previous     *bench.Run
+	compareOpts  bench.CompareOptions
+	watchErr     error
 }
 
 func NewModel(runs []bench.Run, threshold float64) Model {
 	vp := viewport.New(80, 20)
 	vp.Style = lipgloss.NewStyle()
 	return Model{
-		runs:       runs,
-		threshold:  threshold,
-		width:      80,
-		height:     24,
-		currentTab: 0,
-		viewport:   vp,
+		runs:        runs,
+		threshold:   threshold,
+		width:       80,
+		height:      24,
+		currentTab:  0,
+		viewport:    vp,
+		compareOpts: bench.DefaultCompareOptions(),
 	}
 }
 
@@ -72,21 +93,43 @@ func NewComparisonModel(comparison []bench.ComparisonResult, threshold float64)
 	}
 }
 
-func NewStreamingModel(threshold float64) Model {
+func NewStreamingModel(parser *bench.Parser, threshold float64) Model {
 	vp := viewport.New(80, 20)
 	vp.Style = lipgloss.NewStyle()
+	collector := bench.NewCollector()
+	collector.SetModulePath(parser.ModulePath())
+	parser.OnEvent(collector.Handle)
 	return Model{
-		runs:       []bench.Run{{Suites: []bench.Suite{}}},
-		threshold:  threshold,
-		width:      80,
-		height:     24,
-		currentTab: 0,
-		viewport:   vp,
-		streaming:  true,
-		parser:     bench.NewStreamingParser(),
+		runs:        []bench.Run{{Suites: []bench.Suite{}}},
+		threshold:   threshold,
+		width:       80,
+		height:      24,
+		currentTab:  0,
+		viewport:    vp,
+		streaming:   true,
+		parser:      parser,
+		collector:   collector,
+		compareOpts: bench.DefaultCompareOptions(),
 	}
 }
 
+func NewWatchModel(parser *bench.Parser, threshold float64, opts bench.CompareOptions) Model {
+	m := NewStreamingModel(parser, threshold)
+	m.streaming = false
+	m.watching = true
+	m.compareOpts = opts
+	return m
+}
+
+func (m Model) WithCompareOptions(opts bench.CompareOptions) Model {
+	m.compareOpts = opts
+	return m
+}
+
+func (m Model) Err() error {
+	return m.err
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -97,24 +140,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case BenchmarkLineMsg:
-		suite, benchmark, err := m.parser.ParseLine(msg.Line)
-		if err == nil {
-			if suite != nil {
-				if len(m.runs) == 0 {
-					m.runs = []bench.Run{{Suites: []bench.Suite{}}}
-				}
-				m.runs[0].Suites = append(m.runs[0].Suites, *suite)
-			} else if benchmark != nil {
-				if len(m.runs) > 0 && len(m.runs[0].Suites) > 0 {
-					lastIdx := len(m.runs[0].Suites) - 1
-					m.runs[0].Suites[lastIdx].Benchmarks = append(
-						m.runs[0].Suites[lastIdx].Benchmarks,
-						*benchmark,
-					)
-				}
-			}
+		if err := m.parser.ParseLine(msg.Line); err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+		m.runs[0].Suites = m.collector.Suites()
+
+		if m.ready {
+			m.viewport.SetContent(m.getViewContent())
 		}
+		return m, nil
 
+	case IterationStartMsg:
+		if len(m.runs[0].Suites) > 0 {
+			run := m.runs[0]
+			m.previous = &run
+			if m.baseline == nil {
+				m.baseline = &run
+			}
+		}
+		m.iteration++
+		m.streaming = true
+		m.watchErr = nil
+		m.collector = bench.NewCollector()
+		m.collector.SetModulePath(m.parser.ModulePath())
+		m.parser.OnEvent(m.collector.Handle)
+		m.runs[0] = bench.Run{Suites: []bench.Suite{}}
 		if m.ready {
 			m.viewport.SetContent(m.getViewContent())
 		}
@@ -122,6 +173,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case StreamDoneMsg:
 		m.streaming = false
+		m.parser.Close()
+		m.runs[0].Suites = m.collector.Suites()
+		if m.watching {
+			m.watchErr = msg.Err
+		}
 		if m.ready {
 			m.viewport.SetContent(m.getViewContent())
 		}
@@ -132,6 +188,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showHelp = false
 			return m, nil
 		}
+		if m.showRuns {
+			return m.updateRunList(msg)
+		}
 
 		switch msg.String() {
 		case "q", "ctrl+c":
@@ -140,11 +199,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "?":
 			m.showHelp = true
 			return m, nil
-		case "1", "2", "3":
+		case "1", "2", "3", "4":
 			tabNum := int(msg.String()[0] - '1')
 			maxTab := m.getMaxTab()
 			if tabNum <= maxTab {
 				m.currentTab = tabNum
+				if m.showingTrends() {
+					m.trends = bench.BenchmarkHistory(m.runs, m.compareOpts.MatchProcs)
+				}
 				m.viewport.GotoTop()
 				m.viewport.SetContent(m.getViewContent())
 			}
@@ -165,10 +227,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.viewport.SetContent(m.getViewContent())
 			return m, nil
+		case "p":
+			m.pivotParam = m.nextPivotParam()
+			m.viewport.SetContent(m.getViewContent())
+			return m, nil
+		case "r":
+			if len(m.runs) > 1 {
+				m.showRuns = true
+				m.runCursor = m.currentRun
+			}
+			return m, nil
+		case "[":
+			if !m.comparedRuns {
+				m = m.selectRun(m.currentRun - 1)
+			}
+			return m, nil
+		case "]":
+			if !m.comparedRuns {
+				m = m.selectRun(m.currentRun + 1)
+			}
+			return m, nil
+		case "esc":
+			if m.comparedRuns {
+				return m.selectRun(m.currentRun), nil
+			}
+			return m, nil
 		case "j", "down":
+			if m.showingTrends() {
+				m.trendIndex = min(m.trendIndex+1, len(m.trends)-1)
+				m.viewport.SetContent(m.getViewContent())
+				return m, nil
+			}
 			m.viewport.ScrollDown(1)
 			return m, nil
 		case "k", "up":
+			if m.showingTrends() {
+				m.trendIndex = max(m.trendIndex-1, 0)
+				m.viewport.SetContent(m.getViewContent())
+				return m, nil
+			}
 			m.viewport.ScrollUp(1)
 			return m, nil
 		case "d", "ctrl+d":
@@ -216,16 +313,119 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+func (m Model) updateRunList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	case "esc", "r":
+		m.showRuns = false
+	case "j", "down":
+		m.runCursor = min(m.runCursor+1, len(m.runs)-1)
+	case "k", "up":
+		m.runCursor = max(m.runCursor-1, 0)
+	case "g", "home":
+		m.runCursor = 0
+	case "G", "end":
+		m.runCursor = len(m.runs) - 1
+	case "enter":
+		m.showRuns = false
+		return m.selectRun(m.runCursor), nil
+	case " ", "x":
+		if i := slices.Index(m.selectedRuns, m.runCursor); i >= 0 {
+			m.selectedRuns = slices.Delete(m.selectedRuns, i, i+1)
+		} else {
+			m.selectedRuns = append(m.selectedRuns, m.runCursor)
+			if len(m.selectedRuns) > 2 {
+				m.selectedRuns = m.selectedRuns[1:]
+			}
+		}
+	case "c":
+		if len(m.selectedRuns) == 2 {
+			m.showRuns = false
+			return m.compareSelectedRuns(), nil
+		}
+	}
+	return m, nil
+}
+
+func (m Model) selectRun(i int) Model {
+	if i < 0 || i >= len(m.runs) {
+		return m
+	}
+	if m.comparedRuns {
+		m.comparison = nil
+		m.comparedRuns = false
+		m.currentTab = 0
+	}
+	m.currentRun = i
+	m.viewport.GotoTop()
+	m.viewport.SetContent(m.getViewContent())
+	return m
+}
+
+func (m Model) compareSelectedRuns() Model {
+	if m.runs[m.selectedRuns[1]].Date < m.runs[m.selectedRuns[0]].Date {
+		m.selectedRuns = []int{m.selectedRuns[1], m.selectedRuns[0]}
+	}
+	before, after := m.runs[m.selectedRuns[0]], m.runs[m.selectedRuns[1]]
+
+	results, err := bench.CompareTwoRuns(before, after, m.compareOpts)
+	if err != nil || len(results) == 0 {
+		return m
+	}
+
+	m.comparison = results
+	m.comparedRuns = true
+	m.currentTab = 0
+	m.viewport.GotoTop()
+	m.viewport.SetContent(m.getViewContent())
+	return m
+}
+
+func (m Model) paramKeys() []string {
+	var benchmarks []bench.Benchmark
+	if len(m.comparison) > 0 {
+		for _, r := range m.comparison {
+			benchmarks = append(benchmarks, bench.Benchmark{Params: r.Params})
+		}
+	} else if len(m.runs) > 0 {
+		for _, suite := range m.runs[m.currentRun].Suites {
+			benchmarks = append(benchmarks, suite.Benchmarks...)
+		}
+	}
+	return bench.ParamKeys(benchmarks)
+}
+
+func (m Model) nextPivotParam() string {
+	keys := m.paramKeys()
+	if len(keys) == 0 {
+		return ""
+	}
+	i := slices.Index(keys, m.pivotParam)
+	if i == len(keys)-1 {
+		return ""
+	}
+	return keys[i+1]
+}
+
 func (m Model) getMaxTab() int {
 	if len(m.comparison) > 0 {
 		return 2
 	}
+	if len(m.runs) > 1 {
+		return 3
+	}
 	if len(m.runs) > 0 {
 		return 2
 	}
 	return 0
 }
 
+func (m Model) showingTrends() bool {
+	return len(m.comparison) == 0 && len(m.runs) > 1 && m.currentTab == 3
+}
+
 func (m Model) getViewContent() string {
 	if len(m.comparison) > 0 {
 		return m.renderComparisonView()
@@ -237,6 +437,8 @@ func (m Model) getViewContent() string {
 			return m.renderMemoryUsageView()
 		case 2:
 			return m.renderBenchmarkOutputView()
+		case 3:
+			return m.renderTrendView()
 		}
 	}
 	return renderNoData("No benchmark data available")
@@ -250,6 +452,9 @@ func (m Model) View() string {
 	if m.showHelp {
 		return m.renderHelpModal()
 	}
+	if m.showRuns {
+		return m.renderRunList()
+	}
 
 	viewportContent := m.viewport.View()
 	scrollbar := m.renderScrollbar()
@@ -264,6 +469,18 @@ func (m Model) View() string {
 }
 
 func (m Model) renderHelp() string {
+	if m.watching {
+		return footerStyle.Render("?: help | q: quit | 1/2/3: tabs | j/k: scroll | watching for changes")
+	}
+	if m.comparedRuns {
+		return footerStyle.Render("?: help | q: quit | 1/2/3: tabs | j/k: scroll | esc: back to run")
+	}
+	if len(m.runs) > 1 {
+		if m.showingTrends() {
+			return footerStyle.Render("?: help | q: quit | 1-4: tabs | j/k: benchmark | r: runs | [/]: prev/next run")
+		}
+		return footerStyle.Render("?: help | q: quit | 1-4: tabs | j/k: scroll | r: runs | [/]: prev/next run")
+	}
 	return footerStyle.Render("?: help | q: quit | 1/2/3: tabs | j/k: scroll")
 }
 
@@ -313,23 +530,37 @@ func (m Model) renderExecutionTimeView() string {
 	}
 
 	var sections []string
-	run := m.runs[0]
+	run := m.runs[m.currentRun]
+
+	if m.watching {
+		sections = append(sections, m.renderWatchStatus())
+	}
 
 	for _, suite := range run.Suites {
 		if len(suite.Benchmarks) > 0 {
-			header := cardTitleStyle.Render(fmt.Sprintf("%s (%s/%s)", suite.Pkg, suite.Goos, suite.Goarch))
+			header := cardTitleStyle.Render(fmt.Sprintf("%s (%s/%s)", suite.DisplayPath(), suite.Goos, suite.Goarch))
 			sections = append(sections, header)
 
 			timeChart := m.renderBenchmarkTimeChart(suite)
 			if timeChart != "" {
 				sections = append(sections, timeChart)
 			}
+
+			if m.pivotParam != "" {
+				table := bench.PivotBenchmarks(suite.Benchmarks, m.pivotParam, bench.UnitNsPerOp)
+				if len(table.Rows) > 0 {
+					sections = append(sections, m.renderPivotTable(table))
+				}
+			}
 		}
 	}
 
 	if len(sections) == 0 {
 		return renderNoData("No execution time data available")
 	}
+	if len(run.Suites) == 0 && m.watching {
+		sections = append(sections, renderNoData("Waiting for benchmark results"))
+	}
 
 	return strings.Join(sections, "\n\n")
 }
@@ -340,11 +571,11 @@ func (m Model) renderMemoryUsageView() string {
 	}
 
 	var sections []string
-	run := m.runs[0]
+	run := m.runs[m.currentRun]
 
 	for _, suite := range run.Suites {
 		if len(suite.Benchmarks) > 0 {
-			header := cardTitleStyle.Render(fmt.Sprintf("%s (%s/%s)", suite.Pkg, suite.Goos, suite.Goarch))
+			header := cardTitleStyle.Render(fmt.Sprintf("%s (%s/%s)", suite.DisplayPath(), suite.Goos, suite.Goarch))
 			sections = append(sections, header)
 
 			memChart := m.renderBenchmarkMemoryChart(suite)
@@ -367,7 +598,7 @@ func (m Model) renderBenchmarkOutputView() string {
 	}
 
 	var sections []string
-	run := m.runs[0]
+	run := m.runs[m.currentRun]
 
 	header := m.renderHeader(run)
 	sections = append(sections, header)
@@ -377,6 +608,12 @@ func (m Model) renderBenchmarkOutputView() string {
 		sections = append(sections, suiteSection)
 	}
 
+	if len(m.runs) > 1 {
+		if history := m.renderElapsedHistory(); history != "" {
+			sections = append(sections, history)
+		}
+	}
+
 	return strings.Join(sections, "\n\n")
 }
 
@@ -397,6 +634,14 @@ func (m Model) renderHeader(run bench.Run) string {
 		fmt.Sprintf("Version: %s", renderValue(run.Version)),
 		fmt.Sprintf("Date: %s", renderDate(run.Date)),
 		fmt.Sprintf("Tags: %s", renderTags(run.Tags)),
+		fmt.Sprintf("Commit: %s", renderCommit(run.Git)),
+		fmt.Sprintf("CPU: %s", renderValue(strings.Join(run.CPUs(), ", "))),
+		fmt.Sprintf("Elapsed: %s", renderValue(bench.FormatElapsed(run.Elapsed()))),
+	}
+
+	config := run.Config()
+	for _, key := range bench.ConfigKeys(config) {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, strings.Join(config[key], ", ")))
 	}
 
 	return cardStyle.Width(m.width - 4).Render(
@@ -408,12 +653,263 @@ func (m Model) renderHeader(run bench.Run) string {
 func (m Model) renderSuite(suite bench.Suite) string {
 	var lines []string
 
-	lines = append(lines, cardTitleStyle.Render(
-		fmt.Sprintf("%s (%s/%s)", suite.Pkg, suite.Goos, suite.Goarch),
-	))
+	title := fmt.Sprintf("%s (%s/%s)", suite.DisplayPath(), suite.Goos, suite.Goarch)
+	if suite.Elapsed > 0 {
+		title += " " + bench.FormatElapsed(suite.Elapsed)
+	}
+	lines = append(lines, cardTitleStyle.Render(title))
 
+	group := ""
 	for _, b := range suite.Benchmarks {
-		lines = append(lines, renderBenchmark(b))
+		segments := b.Segments()
+		if len(segments) == 1 {
+			group = ""
+			lines = append(lines, renderBenchmark(b, b.FullName()))
+			continue
+		}
+
+		if segments[0] != group {
+			group = segments[0]
+			lines = append(lines, neutralStyle.Render(group))
+		}
+		label := "  " + strings.Join(segments[1:], "/")
+		if b.Procs > 0 {
+			label = fmt.Sprintf("%s-%d", label, b.Procs)
+		}
+		lines = append(lines, renderBenchmark(b, label))
+	}
+
+	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderElapsedHistory() string {
+	series := bench.ElapsedHistory(m.runs)
+	if len(series) == 0 {
+		return ""
+	}
+
+	lines := []string{cardTitleStyle.Render("Package Wall Time")}
+	for _, e := range series {
+		parts := []string{benchNameStyle.Render(e.Path)}
+		for _, v := range e.Values {
+			parts = append(parts, valueStyle.Render(renderValue(bench.FormatElapsed(v))))
+		}
+
+		line := lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+		if pct, ok := e.Change(); ok {
+			style := neutralStyle
+			if pct > m.threshold {
+				style = regressionStyle
+			} else if pct < -m.threshold {
+				style = improvementStyle
+			}
+			line += style.PaddingLeft(2).Render(fmt.Sprintf("%+.1f%%", pct))
+		}
+		lines = append(lines, line)
+	}
+
+	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderWatchStatus() string {
+	state := "waiting for changes"
+	if m.streaming {
+		state = "running"
+	} else if m.watchErr != nil {
+		state = "failed"
+	}
+
+	title := fmt.Sprintf("Iteration %d (%s)", m.iteration, state)
+	if m.iteration == 0 {
+		title = "Watching for changes"
+	}
+	lines := []string{cardTitleStyle.Render(title)}
+	if m.watchErr != nil {
+		lines = append(lines, regressionStyle.Render(m.watchErr.Error()))
+	}
+
+	var vsPrevious, vsBaseline map[string]bench.ComparisonResult
+	if m.previous != nil {
+		vsPrevious = m.compareWith(*m.previous)
+	}
+	if m.baseline != nil {
+		vsBaseline = m.compareWith(*m.baseline)
+	}
+
+	nameWidth := 36
+	cellWidth := 14
+	lines = append(lines, lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		neutralStyle.Width(nameWidth).Render("Benchmark"),
+		neutralStyle.Width(cellWidth).Align(lipgloss.Right).Render("ns/op"),
+		neutralStyle.Width(cellWidth).Align(lipgloss.Right).Render("vs previous"),
+		neutralStyle.Width(cellWidth).Align(lipgloss.Right).Render("vs baseline"),
+	))
+
+	suites := m.runs[0].Suites
+	for _, suite := range suites {
+		for _, b := range suite.Benchmarks {
+			name := suite.Pkg + "/" + b.FullName()
+			label := b.FullName()
+			if len(suites) > 1 {
+				label = suite.DisplayPath() + "/" + label
+			}
+			lines = append(lines, lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				lipgloss.NewStyle().Width(nameWidth).Render(truncateName(label, nameWidth)),
+				lipgloss.NewStyle().Width(cellWidth).Align(lipgloss.Right).Render(fmt.Sprintf("%.2f", b.NsPerOp)),
+				m.renderWatchDelta(vsPrevious, name, suite.Pkg+"/"+b.Name, cellWidth),
+				m.renderWatchDelta(vsBaseline, name, suite.Pkg+"/"+b.Name, cellWidth),
+			))
+		}
+	}
+
+	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) compareWith(run bench.Run) map[string]bench.ComparisonResult {
+	results, err := bench.CompareTwoRuns(run, m.runs[0], m.compareOpts)
+	if err != nil {
+		return nil
+	}
+
+	byName := make(map[string]bench.ComparisonResult, len(results))
+	for _, r := range bench.FilterByKind(results, bench.ComparisonMatched) {
+		byName[r.Name] = r
+	}
+	return byName
+}
+
+func (m Model) renderWatchDelta(results map[string]bench.ComparisonResult, name, short string, width int) string {
+	cell := neutralStyle.Width(width).Align(lipgloss.Right)
+	r, ok := results[name]
+	if !ok {
+		r, ok = results[short]
+	}
+	if !ok {
+		return cell.Render("—")
+	}
+	if r.NsPerOpSig.Tested && !r.NsPerOpSig.Significant {
+		return cell.Render(fmt.Sprintf("~ p=%.2f", r.NsPerOpSig.PValue))
+	}
+
+	style := GetChangeStyle(r.NsPerOpPct, r.ThresholdFor(bench.UnitNsPerOp, m.threshold))
+	return style.Width(width).Align(lipgloss.Right).Render(fmt.Sprintf("%+.1f%%", r.NsPerOpPct))
+}
+
+func (m Model) renderTrendView() string {
+	if len(m.trends) == 0 {
+		return renderNoData("No benchmark history available")
+	}
+
+	selected := min(m.trendIndex, len(m.trends)-1)
+	return m.renderTrendDetail(m.trends[selected], selected, len(m.trends)) + "\n\n" + m.renderTrendList(m.trends, selected)
+}
+
+func (m Model) renderTrendDetail(t bench.BenchmarkTrend, index, total int) string {
+	width := max(m.width-30, 10)
+	title := fmt.Sprintf("%s/%s (%d/%d)", t.Path, t.FullName(), index+1, total)
+	if len(m.runs) > width {
+		title += fmt.Sprintf(" · last %d of %d runs", width, len(m.runs))
+	}
+	lines := []string{cardTitleStyle.Render(title)}
+
+	for _, unit := range bench.TrendUnits() {
+		if !t.Has(unit) {
+			continue
+		}
+		values := t.Values[unit]
+		if len(values) > width {
+			values = values[len(values)-width:]
+		}
+		lo, hi, last, _ := sparkRange(values)
+
+		chart := Sparkline{Width: width, Height: 4, Values: values, Color: primaryColor}
+		axis := []string{"max " + formatRawValue(hi), "", "", "min " + formatRawValue(lo)}
+
+		summary := fmt.Sprintf("last %s %s", formatRawValue(last), unit)
+		if pct, ok := t.Change(unit); ok {
+			summary += "  " + GetChangeStyle(pct, m.threshold).Render(fmt.Sprintf("%+.1f%% since first run", pct))
+		}
+
+		lines = append(lines,
+			neutralStyle.Render(unit),
+			lipgloss.JoinHorizontal(lipgloss.Top, chart.Render(), neutralStyle.PaddingLeft(1).Render(strings.Join(axis, "\n"))),
+			summary,
+			"",
+		)
+	}
+
+	return cardStyle.Width(m.width - 4).Render(strings.TrimSuffix(strings.Join(lines, "\n"), "\n"))
+}
+
+func (m Model) renderTrendList(trends []bench.BenchmarkTrend, selected int) string {
+	nameWidth := 40
+	sparkWidth := min(len(m.runs), 30)
+
+	lines := []string{cardTitleStyle.Render("Benchmarks (ns/op)")}
+	for i, t := range trends {
+		cursor := "  "
+		style := lipgloss.NewStyle()
+		if i == selected {
+			cursor = "▶ "
+			style = style.Foreground(primaryColor).Bold(true)
+		}
+
+		spark := Sparkline{Width: sparkWidth, Height: 1, Values: t.Values[bench.UnitNsPerOp], Color: secondaryColor}
+		_, _, last, ok := sparkRange(t.Values[bench.UnitNsPerOp])
+		value := "—"
+		if ok {
+			value = formatRawValue(last)
+		}
+
+		lines = append(lines, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			style.Width(nameWidth).Render(cursor+truncateName(t.Path+"/"+t.FullName(), nameWidth-2)),
+			lipgloss.NewStyle().Width(sparkWidth+2).Render(spark.Render()),
+			style.Width(10).Align(lipgloss.Right).Render(value),
+		))
+	}
+
+	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
+}
+
+func (m Model) renderPivotTable(table bench.PivotTable) string {
+	labelWidth := 36
+	cellWidth := 12
+
+	title := fmt.Sprintf("%s by %s", table.Unit, table.Param)
+	if table.Delta {
+		title = fmt.Sprintf("%s change by %s", table.Unit, table.Param)
+	}
+
+	header := []string{neutralStyle.Width(labelWidth).Render("Benchmark")}
+	for _, c := range table.Columns {
+		header = append(header, neutralStyle.Width(cellWidth).Align(lipgloss.Right).
+			Render(truncateName(table.Param+"="+c, cellWidth)))
+	}
+
+	lines := []string{
+		cardTitleStyle.Render(title),
+		lipgloss.JoinHorizontal(lipgloss.Top, header...),
+	}
+
+	for _, row := range table.Rows {
+		cells := []string{lipgloss.NewStyle().Width(labelWidth).Render(truncateName(row.Label, labelWidth))}
+		for _, c := range table.Columns {
+			cell := neutralStyle.Width(cellWidth).Align(lipgloss.Right)
+			v, ok := row.Values[c]
+			switch {
+			case !ok:
+				cells = append(cells, cell.Render("-"))
+			case table.Delta:
+				style := GetChangeStyle(v, m.threshold).Width(cellWidth).Align(lipgloss.Right)
+				cells = append(cells, style.Render(fmt.Sprintf("%+.1f%%", v)))
+			default:
+				cells = append(cells, cell.Render(formatRawValue(v)))
+			}
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
 	}
 
 	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
@@ -423,18 +919,21 @@ func (m Model) renderComparisonSummary() string {
 	regressions := 0
 	improvements := 0
 
-	for _, r := range m.comparison {
+	compared := bench.FilterByKind(m.comparison, bench.ComparisonMatched)
+	for _, r := range compared {
 		if r.IsRegression(m.threshold) {
 			regressions++
-		} else if r.NsPerOpPct < -m.threshold {
+		} else if r.IsImprovement(m.threshold) {
 			improvements++
 		}
 	}
 
 	lines := []string{
-		fmt.Sprintf("Total Benchmarks: %s", metricValueStyle.Render(fmt.Sprintf("%d", len(m.comparison)))),
+		fmt.Sprintf("Total Benchmarks: %s", metricValueStyle.Render(fmt.Sprintf("%d", len(compared)))),
 		fmt.Sprintf("Regressions: %s", regressionStyle.Render(fmt.Sprintf("%d", regressions))),
 		fmt.Sprintf("Improvements: %s", improvementStyle.Render(fmt.Sprintf("%d", improvements))),
+		fmt.Sprintf("Added: %s", metricValueStyle.Render(fmt.Sprintf("%d", len(bench.FilterByKind(m.comparison, bench.ComparisonAdded))))),
+		fmt.Sprintf("Removed: %s", metricValueStyle.Render(fmt.Sprintf("%d", len(bench.FilterByKind(m.comparison, bench.ComparisonRemoved))))),
 	}
 
 	return cardStyle.Width(m.width - 4).Render(
@@ -449,7 +948,7 @@ func (m Model) renderBarChart() string {
 	}
 
 	var bars []BarValue
-	for _, r := range m.comparison {
+	for _, r := range bench.FilterByKind(m.comparison, bench.ComparisonMatched) {
 		color := barNeutral
 		if r.NsPerOpPct > 0 {
 			color = barNegative
@@ -470,10 +969,19 @@ func (m Model) renderBarChart() string {
 		ShowPercent: true,
 	}
 
-	return cardStyle.Width(m.width - 4).Render(
+	content := cardStyle.Width(m.width - 4).Render(
 		cardTitleStyle.Render("Time Changes") + "\n" +
 			chart.Render(),
 	)
+
+	if m.pivotParam != "" {
+		table := bench.PivotResults(m.comparison, m.pivotParam)
+		if len(table.Rows) > 0 {
+			content += "\n\n" + m.renderPivotTable(table)
+		}
+	}
+
+	return content
 }
 
 func (m Model) renderComparisonTable() string {
@@ -503,9 +1011,13 @@ func (m Model) renderComparisonTable() string {
 		Foreground(mutedColor).
 		Render(strings.Repeat("─", m.width-8)))
 
-	for _, r := range m.comparison {
-		changeStyle := GetChangeStyle(r.NsPerOpPct, m.threshold)
+	for _, r := range bench.FilterByKind(m.comparison, bench.ComparisonMatched) {
+		changeStyle := GetChangeStyle(r.NsPerOpPct, r.ThresholdFor(bench.UnitNsPerOp, m.threshold))
 		changeStr := fmt.Sprintf("%+.1f%%", r.NsPerOpPct)
+		if r.NsPerOpSig.Tested && !r.NsPerOpSig.Significant {
+			changeStyle = neutralStyle
+			changeStr = fmt.Sprintf("~ p=%.2f", r.NsPerOpSig.PValue)
+		}
 
 		row := lipgloss.JoinHorizontal(
 			lipgloss.Top,
@@ -513,13 +1025,71 @@ func (m Model) renderComparisonTable() string {
 			lipgloss.NewStyle().Width(oldWidth).Align(lipgloss.Right).Render(fmt.Sprintf("%.0f", r.OldNsPerOp)),
 			lipgloss.NewStyle().Width(newWidth).Align(lipgloss.Right).Render(fmt.Sprintf("%.0f", r.NewNsPerOp)),
 			changeStyle.Width(deltaWidth).Align(lipgloss.Right).Render(changeStr),
+			neutralStyle.PaddingLeft(2).Render(r.Rule),
 		)
 		lines = append(lines, row)
+		for _, mc := range r.ExtraMetrics() {
+			lines = append(lines, m.renderMetricRow(r, mc, benchWidth, oldWidth, newWidth, deltaWidth))
+		}
+		for _, v := range r.BudgetViolations {
+			lines = append(lines, regressionStyle.PaddingLeft(2).Render("over budget: "+v))
+		}
+		if r.NewlyFailing() {
+			lines = append(lines, regressionStyle.PaddingLeft(2).Render("now "+r.NewStatus))
+			for _, f := range r.Failure {
+				lines = append(lines, neutralStyle.PaddingLeft(4).Render(f))
+			}
+		}
 	}
 
+	lines = append(lines, m.renderPresenceSection("Added", bench.FilterByKind(m.comparison, bench.ComparisonAdded))...)
+	lines = append(lines, m.renderPresenceSection("Removed", bench.FilterByKind(m.comparison, bench.ComparisonRemoved))...)
+
 	return cardStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
 }
 
+func (m Model) renderMetricRow(r bench.ComparisonResult, mc bench.MetricComparison, benchWidth, oldWidth, newWidth, deltaWidth int) string {
+	label := "  └ " + mc.Unit
+	if mc.HigherIsBetter {
+		label += " ↑"
+	}
+
+	changeStyle := GetChangeStyle(mc.Worsening(), r.ThresholdFor(mc.Unit, m.threshold))
+	changeStr := fmt.Sprintf("%+.1f%%", mc.Pct)
+	if mc.Sig.Tested && !mc.Sig.Significant {
+		changeStyle = neutralStyle
+		changeStr = fmt.Sprintf("~ p=%.2f", mc.Sig.PValue)
+	}
+
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		neutralStyle.Width(benchWidth).Render(truncateName(label, benchWidth)),
+		neutralStyle.Width(oldWidth).Align(lipgloss.Right).Render(formatRawValue(mc.Old)),
+		neutralStyle.Width(newWidth).Align(lipgloss.Right).Render(formatRawValue(mc.New)),
+		changeStyle.Width(deltaWidth).Align(lipgloss.Right).Render(changeStr),
+	)
+}
+
+func (m Model) renderPresenceSection(title string, results []bench.ComparisonResult) []string {
+	if len(results) == 0 {
+		return nil
+	}
+
+	lines := []string{"", cardTitleStyle.Render(fmt.Sprintf("%s (%d)", title, len(results)))}
+	for _, r := range results {
+		value := r.NewNsPerOp
+		if r.Kind == bench.ComparisonRemoved {
+			value = r.OldNsPerOp
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			lipgloss.NewStyle().Width(35).Render(truncateName(r.Name, 35)),
+			lipgloss.NewStyle().Width(24).Align(lipgloss.Right).Render(fmt.Sprintf("%.0f ns/op", value)),
+		))
+	}
+	return lines
+}
+
 func (m Model) renderBenchmarkTimeChart(suite bench.Suite) string {
 	var bars []BarValue
 	maxTime := 0.0
@@ -547,7 +1117,7 @@ func (m Model) renderBenchmarkTimeChart(suite bench.Suite) string {
 			}
 
 			bars = append(bars, BarValue{
-				Label: truncateName(b.Name, 30),
+				Label: truncateName(b.FullName(), 30),
 				Value: b.NsPerOp,
 				Color: color,
 			})
@@ -599,7 +1169,7 @@ func (m Model) renderBenchmarkMemoryChart(suite bench.Suite) string {
 			}
 
 			bars = append(bars, BarValue{
-				Label: truncateName(b.Name, 30),
+				Label: truncateName(b.FullName(), 30),
 				Value: b.Mem.BytesPerOp,
 				Color: color,
 			})
@@ -652,6 +1222,9 @@ func (m Model) renderTabs() string {
 		tabs = []string{"Summary", "Time Changes", "Details"}
 	} else if len(m.runs) > 0 {
 		tabs = []string{"Execution Time", "Memory Usage", "Benchmark Output"}
+		if len(m.runs) > 1 {
+			tabs = append(tabs, "Trends")
+		}
 	} else {
 		return ""
 	}
@@ -669,6 +1242,14 @@ func (m Model) renderTabs() string {
 		parts = append(parts, style.Render(fmt.Sprintf("%d %s", i+1, tab)))
 	}
 
+	if m.comparedRuns {
+		parts = append(parts, neutralStyle.Padding(0, 2).Render(fmt.Sprintf("comparing runs %d → %d",
+			m.selectedRuns[0]+1, m.selectedRuns[1]+1)))
+	} else if len(m.runs) > 1 {
+		parts = append(parts, neutralStyle.Padding(0, 2).Render(fmt.Sprintf("run %d/%d %s",
+			m.currentRun+1, len(m.runs), runLabel(m.runs[m.currentRun]))))
+	}
+
 	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
 }
 
@@ -698,12 +1279,16 @@ func (m Model) renderHelpModal() string {
 	}{
 		{"?", "Toggle this help"},
 		{"q", "Quit"},
-		{"1/2/3", "Switch tabs"},
-		{"j/k", "Scroll down/up"},
+		{"1-4", "Switch tabs"},
+		{"j/k", "Scroll down/up (Trends: next/previous benchmark)"},
 		{"d/u", "Half page down/up"},
 		{"g/G", "Go to top/bottom"},
 		{"s", "Sort by name"},
 		{"S", "Sort by value"},
+		{"p", "Pivot by next parameter"},
+		{"r", "Browse runs"},
+		{"[/]", "Previous/next run"},
+		{"esc", "Leave run comparison"},
 	}
 
 	var lines []string
@@ -733,6 +1318,78 @@ func (m Model) renderHelpModal() string {
 	)
 }
 
+func (m Model) renderRunList() string {
+	cursorWidth, markWidth, indexWidth := 2, 4, 5
+	versionWidth, dateWidth, commitWidth, tagsWidth := 16, 18, 14, 24
+
+	header := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		neutralStyle.Width(cursorWidth+markWidth+indexWidth).Render("#"),
+		neutralStyle.Width(versionWidth).Render("Version"),
+		neutralStyle.Width(dateWidth).Render("Date"),
+		neutralStyle.Width(commitWidth).Render("Commit"),
+		neutralStyle.Width(tagsWidth).Render("Tags"),
+	)
+	lines := []string{cardTitleStyle.Render(fmt.Sprintf("Runs (%d)", len(m.runs))), header}
+
+	for i, run := range m.runs {
+		cursor, mark := "", "[ ]"
+		if i == m.runCursor {
+			cursor = "▶"
+		}
+		if slices.Contains(m.selectedRuns, i) {
+			mark = "[x]"
+		}
+		commit := "—"
+		if run.Git != nil {
+			commit = run.Git.ShortCommit()
+		}
+
+		style := lipgloss.NewStyle()
+		if i == m.currentRun {
+			style = style.Foreground(primaryColor).Bold(true)
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			style.Width(cursorWidth).Render(cursor),
+			style.Width(markWidth).Render(mark),
+			style.Width(indexWidth).Render(fmt.Sprintf("%d", i+1)),
+			style.Width(versionWidth).Render(truncateName(renderValue(run.Version), versionWidth-1)),
+			style.Width(dateWidth).Render(renderRunDate(run.Date)),
+			style.Width(commitWidth).Render(commit),
+			style.Width(tagsWidth).Render(truncateName(renderTags(run.Tags), tagsWidth)),
+		))
+	}
+
+	lines = append(lines, "", footerStyle.Render("j/k: move | enter: inspect | space: mark | c: compare marked | esc: close"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		cardStyle.Render(strings.Join(lines, "\n")),
+	)
+}
+
+func runLabel(run bench.Run) string {
+	switch {
+	case run.Version != "":
+		return run.Version
+	case run.Git != nil:
+		return run.Git.ShortCommit()
+	default:
+		return renderRunDate(run.Date)
+	}
+}
+
+func renderRunDate(ts int64) string {
+	if ts == 0 {
+		return "—"
+	}
+	return time.Unix(ts, 0).Format("2006-01-02 15:04")
+}
+
 func renderContainer(content, tabs, footer string) string {
 	return "\n" + tabs + "\n\n" + content + "\n\n" + footer + "\n"
 }
@@ -746,13 +1403,16 @@ func renderNoData(message string) string {
 	)
 }
 
-func renderBenchmark(b bench.Benchmark) string {
+func renderBenchmark(b bench.Benchmark, label string) string {
 	var parts []string
 
-	parts = append(parts, benchNameStyle.Render(b.Name))
+	parts = append(parts, benchNameStyle.Render(label))
 
 	if b.NsPerOp > 0 {
 		parts = append(parts, valueStyle.Render(fmt.Sprintf("%.2f ns/op", b.NsPerOp)))
+		if st, ok := b.Stats[bench.UnitNsPerOp]; ok && st.N > 1 {
+			parts = append(parts, valueStyle.Render(fmt.Sprintf("±%.1f%% n=%d", st.CV, st.N)))
+		}
 	}
 
 	if b.Mem != nil {
@@ -762,9 +1422,37 @@ func renderBenchmark(b bench.Benchmark) string {
 		if b.Mem.AllocsPerOp > 0 {
 			parts = append(parts, valueStyle.Render(fmt.Sprintf("%.0f allocs/op", b.Mem.AllocsPerOp)))
 		}
+		if b.Mem.MBPerSec > 0 {
+			parts = append(parts, valueStyle.Render(fmt.Sprintf("%.2f MB/s", b.Mem.MBPerSec)))
+		}
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+	if b.Status != "" && b.Status != bench.StatusOK {
+		style := neutralStyle
+		if bench.IsFailure(b.Status) {
+			style = regressionStyle
+		}
+		parts = append(parts, style.PaddingLeft(2).Render(strings.ToUpper(b.Status)))
+	}
+
+	line := lipgloss.JoinHorizontal(lipgloss.Top, parts...)
+	for _, f := range b.Failure {
+		line += "\n" + neutralStyle.PaddingLeft(2).Render(f)
+	}
+	for _, l := range b.Log {
+		line += "\n" + lipgloss.NewStyle().Foreground(mutedColor).PaddingLeft(2).Render("│ "+l)
+	}
+
+	units := slices.Sorted(maps.Keys(b.Custom))
+	if len(units) == 0 {
+		return line
+	}
+
+	custom := make([]string, 0, len(units))
+	for _, unit := range units {
+		custom = append(custom, fmt.Sprintf("%s %s", formatRawValue(b.Custom[unit]), unit))
+	}
+	return line + "\n" + neutralStyle.PaddingLeft(2).Render(strings.Join(custom, "  "))
 }
 
 func renderValue(v string) string {
@@ -774,6 +1462,24 @@ func renderValue(v string) string {
 	return v
 }
 
+func renderCommit(git *bench.GitInfo) string {
+	if git == nil {
+		return "—"
+	}
+
+	commit := git.ShortCommit()
+	if git.Branch != "" {
+		commit += " (" + git.Branch + ")"
+	}
+	if git.Dirty {
+		commit += " dirty"
+	}
+	if git.Subject != "" {
+		commit += " " + git.Subject
+	}
+	return commit
+}
+
 func renderTags(tags []string) string {
 	if len(tags) == 0 {
 		return "—"
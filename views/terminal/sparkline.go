@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var sparkLevels = []rune(" ▁▂▃▄▅▆▇█")
+
+type Sparkline struct {
+	Width  int
+	Height int
+	Values []float64
+	Color  lipgloss.Color
+}
+
+func (s *Sparkline) Render() string {
+	values := s.Values
+	if s.Width > 0 && len(values) > s.Width {
+		values = values[len(values)-s.Width:]
+	}
+
+	lo, hi, _, ok := sparkRange(values)
+	if !ok {
+		return ""
+	}
+
+	height := max(s.Height, 1)
+	steps := height*8 - 1
+	levels := make([]int, len(values))
+	for i, v := range values {
+		switch {
+		case math.IsNaN(v):
+			levels[i] = 0
+		case hi == lo:
+			levels[i] = 1 + steps/2
+		default:
+			levels[i] = 1 + int(math.Round((v-lo)/(hi-lo)*float64(steps)))
+		}
+	}
+
+	repeat := 1
+	if s.Width > 0 {
+		repeat = max(s.Width/len(values), 1)
+	}
+
+	style := lipgloss.NewStyle().Foreground(s.Color)
+	rows := make([]string, 0, height)
+	for row := height - 1; row >= 0; row-- {
+		var sb strings.Builder
+		for _, level := range levels {
+			sb.WriteString(strings.Repeat(string(sparkLevels[min(max(level-row*8, 0), 8)]), repeat))
+		}
+		rows = append(rows, style.Render(sb.String()))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func sparkRange(values []float64) (float64, float64, float64, bool) {
+	lo, hi, last := math.Inf(1), math.Inf(-1), math.NaN()
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		lo = min(lo, v)
+		hi = max(hi, v)
+		last = v
+	}
+	return lo, hi, last, !math.IsNaN(last)
+}
@@ -4,10 +4,12 @@ import (
 	_ "embed"
 	"fmt"
 	"html"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -138,6 +140,10 @@ func (g *Generator) generateRunsContent() string {
 
 	sections = append(sections, g.generateSummary())
 
+	if len(g.runs) > 1 {
+		sections = append(sections, g.generateElapsedHistory())
+	}
+
 	sections = append(sections, g.generateTabs())
 
 	return joinSections(sections...)
@@ -183,6 +189,41 @@ func (g *Generator) generateSummary() string {
         </div>`, tags))
 	}
 
+	if run.Git != nil {
+		commit := run.Git.ShortCommit()
+		if run.Git.Branch != "" {
+			commit += " (" + run.Git.Branch + ")"
+		}
+		if run.Git.Dirty {
+			commit += " dirty"
+		}
+		metadata = append(metadata, fmt.Sprintf(`<div class="metadata-item">
+            <span class="metadata-label">Commit:</span>
+            <span class="metadata-value" title="%s">%s</span>
+        </div>`, escapeHTML(run.Git.Subject), escapeHTML(commit)))
+	}
+
+	if cpus := run.CPUs(); len(cpus) > 0 {
+		metadata = append(metadata, fmt.Sprintf(`<div class="metadata-item">
+            <span class="metadata-label">CPU:</span>
+            <span class="metadata-value">%s</span>
+        </div>`, escapeHTML(joinStrings(cpus, ", "))))
+	}
+	config := run.Config()
+	for _, key := range bench.ConfigKeys(config) {
+		metadata = append(metadata, fmt.Sprintf(`<div class="metadata-item">
+            <span class="metadata-label">%s:</span>
+            <span class="metadata-value">%s</span>
+        </div>`, escapeHTML(key), escapeHTML(joinStrings(config[key], ", "))))
+	}
+
+	if elapsed := run.Elapsed(); elapsed > 0 {
+		metadata = append(metadata, fmt.Sprintf(`<div class="metadata-item">
+            <span class="metadata-label">Elapsed:</span>
+            <span class="metadata-value">%s</span>
+        </div>`, bench.FormatElapsed(elapsed)))
+	}
+
 	suiteCount := 0
 	benchCount := 0
 	for _, s := range run.Suites {
@@ -211,10 +252,11 @@ func (g *Generator) generateComparisonSummary() string {
 	regressions := 0
 	improvements := 0
 
-	for _, r := range g.comparison {
+	compared := bench.FilterByKind(g.comparison, bench.ComparisonMatched)
+	for _, r := range compared {
 		if r.IsRegression(g.threshold) {
 			regressions++
-		} else if r.NsPerOpPct < -g.threshold {
+		} else if r.IsImprovement(g.threshold) {
 			improvements++
 		}
 	}
@@ -234,8 +276,18 @@ func (g *Generator) generateComparisonSummary() string {
             <div class="stat-value">%d</div>
             <div class="stat-label">Improvements</div>
         </div>
+        <div class="stat-card">
+            <div class="stat-value">%d</div>
+            <div class="stat-label">Added</div>
+        </div>
+        <div class="stat-card">
+            <div class="stat-value">%d</div>
+            <div class="stat-label">Removed</div>
+        </div>
     </div>
-</section>`, len(g.comparison), regressions, improvements)
+</section>`, len(compared), regressions, improvements,
+		len(bench.FilterByKind(g.comparison, bench.ComparisonAdded)),
+		len(bench.FilterByKind(g.comparison, bench.ComparisonRemoved)))
 }
 
 func (g *Generator) generateTabs() string {
@@ -262,6 +314,9 @@ func (g *Generator) getRunTitle(run bench.Run, index int) string {
 	if run.Version != "" {
 		return run.Version
 	}
+	if run.Git != nil {
+		return run.Git.ShortCommit()
+	}
 	if run.Date > 0 {
 		return time.Unix(run.Date, 0).Format("2006-01-02 15:04")
 	}
@@ -285,13 +340,32 @@ func (g *Generator) generateRunTab(run bench.Run, index int, isActive bool) stri
 func (g *Generator) generateSuiteCard(suite bench.Suite) string {
 	timeChart := g.generateTimeBarChart(suite)
 	memChart := g.generateMemBarChart(suite)
+	extraCharts := g.generateExtraMetricCharts(suite)
+
+	var pivots []string
+	for _, param := range bench.ParamKeys(suite.Benchmarks) {
+		table := bench.PivotBenchmarks(suite.Benchmarks, param, bench.UnitNsPerOp)
+		if len(table.Rows) > 0 {
+			pivots = append(pivots, fmt.Sprintf(`        <div class="chart-section">
+            <h4>%s by %s</h4>
+%s
+        </div>`, escapeHTML(table.Unit), escapeHTML(table.Param), g.generatePivotTable(table)))
+		}
+	}
+	extraCharts = joinSections(extraCharts, joinStrings(pivots, "\n"), g.generateFailures(suite), g.generateLogs(suite))
+
+	elapsed := ""
+	if suite.Elapsed > 0 {
+		elapsed = fmt.Sprintf(`
+            <span class="badge">%s</span>`, bench.FormatElapsed(suite.Elapsed))
+	}
 
 	return fmt.Sprintf(`<div class="card">
     <div class="card-header">
-        <h3>%s</h3>
+        <h3 title="%s">%s</h3>
         <div class="suite-info">
             <span class="badge">%s</span>
-            <span class="badge">%s/%s</span>
+            <span class="badge">%s/%s</span>%s
         </div>
     </div>
     <div class="card-body">
@@ -307,8 +381,130 @@ func (g *Generator) generateSuiteCard(suite bench.Suite) string {
 %s
             </div>
         </div>
+%s
     </div>
-</div>`, suite.Pkg, suite.Go, suite.Goos, suite.Goarch, timeChart, memChart)
+</div>`, escapeHTML(suite.Pkg), escapeHTML(suite.DisplayPath()), suite.Go, suite.Goos, suite.Goarch, elapsed, timeChart, memChart, extraCharts)
+}
+
+func (g *Generator) generateElapsedHistory() string {
+	series := bench.ElapsedHistory(g.runs)
+	if len(series) == 0 {
+		return ""
+	}
+
+	var header []string
+	for i, run := range g.runs {
+		header = append(header, fmt.Sprintf(`<th class="text-right">%s</th>`, escapeHTML(g.getRunTitle(run, i))))
+	}
+
+	var rows []string
+	for _, e := range series {
+		var cells []string
+		for _, v := range e.Values {
+			value := bench.FormatElapsed(v)
+			if value == "" {
+				value = "-"
+			}
+			cells = append(cells, fmt.Sprintf(`<td class="text-right">%s</td>`, value))
+		}
+
+		change := "-"
+		class := ""
+		if pct, ok := e.Change(); ok {
+			change = fmt.Sprintf("%+.1f%%", pct)
+			class = getClassForChange(pct, g.threshold)
+		}
+		rows = append(rows, fmt.Sprintf(`<tr>
+            <td class="bench-name" title="%s">%s</td>
+            %s
+            <td class="text-right %s">%s</td>
+        </tr>`, escapeHTML(e.Pkg), escapeHTML(e.Path), joinStrings(cells, ""), class, change))
+	}
+
+	return fmt.Sprintf(`<section class="comparison-table">
+    <h2>Package Wall Time</h2>
+    <div class="table-wrapper">
+        <table>
+            <thead>
+                <tr>
+                    <th>Package</th>
+                    %s
+                    <th class="text-right">Δ%%</th>
+                </tr>
+            </thead>
+            <tbody>
+%s
+            </tbody>
+        </table>
+    </div>
+</section>`, joinStrings(header, ""), joinStrings(rows, "\n"))
+}
+
+func (g *Generator) generateExtraMetricCharts(suite bench.Suite) string {
+	var units []string
+	for _, b := range suite.Benchmarks {
+		for unit := range b.Metrics() {
+			if !bench.IsCoreMetric(unit) && !slices.Contains(units, unit) {
+				units = append(units, unit)
+			}
+		}
+	}
+	slices.Sort(units)
+
+	var sections []string
+	for _, unit := range units {
+		maxVal := 0.0
+		for _, b := range suite.Benchmarks {
+			if v := b.Metrics()[unit]; v > maxVal {
+				maxVal = v
+			}
+		}
+		if maxVal == 0 {
+			continue
+		}
+
+		greenCount, yellowCount, redCount := 0, 0, 0
+		higherIsBetter := bench.DirectionOf(unit) == bench.HigherIsBetter
+
+		var bars []string
+		for _, b := range suite.Benchmarks {
+			v, ok := b.Metrics()[unit]
+			if !ok || v <= 0 {
+				continue
+			}
+
+			pct := (v / maxVal) * 100
+			if pct < 5 {
+				pct = 5
+			}
+			ranked := v
+			if higherIsBetter {
+				ranked = maxVal - v
+			}
+			color, shade := g.getPerformanceColor(ranked, maxVal, &greenCount, &yellowCount, &redCount)
+
+			bars = append(bars, fmt.Sprintf(`<div class="bar-row">
+            <div class="bar-name">%s</div>
+            <div class="bar-track">
+                <div class="bar %s" style="width: %.1f%%; %s"></div>
+            </div>
+            <div class="bar-value">%s</div>
+        </div>`, escapeHTML(b.FullName()), color, pct, shade, formatValue(v)+formatVariation(b, unit)))
+		}
+
+		direction := "lower is better"
+		if higherIsBetter {
+			direction = "higher is better"
+		}
+		sections = append(sections, fmt.Sprintf(`        <div class="chart-section">
+            <h4>%s (%s)</h4>
+            <div class="bar-chart">
+%s
+            </div>
+        </div>`, escapeHTML(unit), direction, joinStrings(bars, "\n")))
+	}
+
+	return joinStrings(sections, "\n")
 }
 
 func (g *Generator) generateTimeBarChart(suite bench.Suite) string {
@@ -342,7 +538,7 @@ func (g *Generator) generateTimeBarChart(suite bench.Suite) string {
                 <div class="bar %s" style="width: %.1f%%; %s"></div>
             </div>
             <div class="bar-value">%s</div>
-        </div>`, escapeHTML(b.Name), color, pct, shade, formatValue(b.NsPerOp)))
+        </div>`, escapeHTML(b.FullName()), color, pct, shade, formatValue(b.NsPerOp)+formatVariation(b, bench.UnitNsPerOp)))
 	}
 
 	return joinStrings(bars, "\n")
@@ -379,7 +575,7 @@ func (g *Generator) generateMemBarChart(suite bench.Suite) string {
                 <div class="bar %s" style="width: %.1f%%; %s"></div>
             </div>
             <div class="bar-value">%s</div>
-        </div>`, escapeHTML(b.Name), color, pct, shade, formatBytes(b.Mem.BytesPerOp)))
+        </div>`, escapeHTML(b.FullName()), color, pct, shade, formatBytes(b.Mem.BytesPerOp)+formatVariation(b, bench.UnitBytesPerOp)))
 	}
 
 	return joinStrings(bars, "\n")
@@ -428,6 +624,14 @@ func formatValue(v float64) string {
 	return fmt.Sprintf("%.2f", v)
 }
 
+func formatVariation(b bench.Benchmark, unit string) string {
+	st, ok := b.Stats[unit]
+	if !ok || st.N <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" ±%.0f%%", st.CV)
+}
+
 func formatBytes(v float64) string {
 	if v >= 1073741824 {
 		return fmt.Sprintf("%.1f GB", v/1073741824)
@@ -439,6 +643,43 @@ func formatBytes(v float64) string {
 	return fmt.Sprintf("%.0f B", v)
 }
 
+func (g *Generator) generateFailures(suite bench.Suite) string {
+	var rows []string
+	for _, b := range suite.Benchmarks {
+		if b.Status != "" && b.Status != bench.StatusOK {
+			rows = append(rows, g.generateBenchmarkRow(b))
+		}
+	}
+	if len(suite.Failure) > 0 {
+		rows = append(rows, fmt.Sprintf(`<pre class="failure-output">%s</pre>`, escapeHTML(joinStrings(suite.Failure, "\n"))))
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`        <div class="chart-section">
+            <h4>Failures (%s)</h4>
+%s
+        </div>`, escapeHTML(suite.Status), joinStrings(rows, "\n"))
+}
+
+func (g *Generator) generateLogs(suite bench.Suite) string {
+	var rows []string
+	for _, b := range suite.Benchmarks {
+		if len(b.Log) > 0 && (b.Status == "" || b.Status == bench.StatusOK) {
+			rows = append(rows, g.generateBenchmarkRow(b))
+		}
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(`        <div class="chart-section">
+            <h4>Benchmark Output</h4>
+%s
+        </div>`, joinStrings(rows, "\n"))
+}
+
 func (g *Generator) generateBenchmarkRow(b bench.Benchmark) string {
 	var metrics []string
 
@@ -450,8 +691,15 @@ func (g *Generator) generateBenchmarkRow(b bench.Benchmark) string {
 	if b.NsPerOp > 0 {
 		metrics = append(metrics, fmt.Sprintf(`<div class="metric">
             <span class="metric-label">Time:</span>
-            <span class="metric-value">%.2f ns/op</span>
-        </div>`, b.NsPerOp))
+            <span class="metric-value">%.2f ns/op%s</span>
+        </div>`, b.NsPerOp, formatVariation(b, bench.UnitNsPerOp)))
+	}
+
+	if b.SampleCount() > 1 {
+		metrics = append(metrics, fmt.Sprintf(`<div class="metric">
+            <span class="metric-label">Samples:</span>
+            <span class="metric-value">%d</span>
+        </div>`, b.SampleCount()))
 	}
 
 	if b.Mem != nil {
@@ -469,10 +717,32 @@ func (g *Generator) generateBenchmarkRow(b bench.Benchmark) string {
 		}
 	}
 
+	for _, unit := range slices.Sorted(maps.Keys(b.Custom)) {
+		metrics = append(metrics, fmt.Sprintf(`<div class="metric">
+            <span class="metric-label">%s:</span>
+            <span class="metric-value">%s</span>
+        </div>`, escapeHTML(unit), formatValue(b.Custom[unit])))
+	}
+
+	if b.Status != "" && b.Status != bench.StatusOK {
+		metrics = append(metrics, fmt.Sprintf(`<div class="metric">
+            <span class="metric-label">Status:</span>
+            <span class="metric-value change-negative">%s</span>
+        </div>`, escapeHTML(b.Status)))
+	}
+
+	var output string
+	if len(b.Failure) > 0 {
+		output = fmt.Sprintf("\n    <pre class=\"failure-output\">%s</pre>", escapeHTML(joinStrings(b.Failure, "\n")))
+	}
+	if len(b.Log) > 0 {
+		output += fmt.Sprintf("\n    <pre class=\"benchmark-log\">%s</pre>", escapeHTML(joinStrings(b.Log, "\n")))
+	}
+
 	return fmt.Sprintf(`<div class="benchmark-item">
     <div class="benchmark-name">%s</div>
-    <div class="benchmark-metrics">%s</div>
-</div>`, b.Name, joinStrings(metrics, "\n"))
+    <div class="benchmark-metrics">%s</div>%s
+</div>`, escapeHTML(b.FullName()), joinStrings(metrics, "\n"), output)
 }
 
 func (g *Generator) generateComparisonCharts() string {
@@ -503,14 +773,15 @@ func (g *Generator) prepareTimeChartData() string {
 	var data []string
 	var colors []string
 
-	for _, r := range g.comparison {
+	for _, r := range bench.FilterByKind(g.comparison, bench.ComparisonMatched) {
 		labels = append(labels, fmt.Sprintf("'%s'", escapeJS(r.Name)))
 		data = append(data, fmt.Sprintf("%.2f", r.NsPerOpPct))
 
 		color := "#64748b"
-		if r.NsPerOpPct > g.threshold {
+		threshold := r.ThresholdFor(bench.UnitNsPerOp, g.threshold)
+		if r.NsPerOpPct > threshold {
 			color = "#ef4444"
-		} else if r.NsPerOpPct < -g.threshold {
+		} else if r.NsPerOpPct < -threshold {
 			color = "#22c55e"
 		}
 		colors = append(colors, color)
@@ -531,15 +802,16 @@ func (g *Generator) prepareMemChartData() string {
 	var data []string
 	var colors []string
 
-	for _, r := range g.comparison {
+	for _, r := range bench.FilterByKind(g.comparison, bench.ComparisonMatched) {
 		if r.OldBytes > 0 || r.NewBytes > 0 {
 			labels = append(labels, fmt.Sprintf("'%s'", escapeJS(r.Name)))
 			data = append(data, fmt.Sprintf("%.2f", r.BytesPct))
 
 			color := "#64748b"
-			if r.BytesPct > g.threshold {
+			threshold := r.ThresholdFor(bench.UnitBytesPerOp, g.threshold)
+			if r.BytesPct > threshold {
 				color = "#ef4444"
-			} else if r.BytesPct < -g.threshold {
+			} else if r.BytesPct < -threshold {
 				color = "#22c55e"
 			}
 			colors = append(colors, color)
@@ -559,20 +831,40 @@ func (g *Generator) prepareMemChartData() string {
 func (g *Generator) generateComparisonTable() string {
 	var rows []string
 
-	for _, r := range g.comparison {
-		timeClass := getClassForChange(r.NsPerOpPct, g.threshold)
-		memClass := getClassForChange(r.BytesPct, g.threshold)
+	for _, r := range bench.FilterByKind(g.comparison, bench.ComparisonMatched) {
+		timeClass, timeChange := formatSignificantChange(r.NsPerOpPct, r.NsPerOpSig, r.ThresholdFor(bench.UnitNsPerOp, g.threshold))
+		memClass, memChange := formatSignificantChange(r.BytesPct, r.BytesSig, r.ThresholdFor(bench.UnitBytesPerOp, g.threshold))
+
+		if r.NewlyFailing() {
+			timeClass, timeChange = "change-negative", escapeHTML(r.NewStatus)
+		}
 
 		rows = append(rows, fmt.Sprintf(`<tr>
-            <td class="bench-name">%s</td>
+            <td class="bench-name">%s%s</td>
             <td class="text-right">%.0f</td>
             <td class="text-right">%.0f</td>
-            <td class="text-right %s">%+.1f%%</td>
+            <td class="text-right %s">%s</td>
             <td class="text-right">%.0f</td>
             <td class="text-right">%.0f</td>
-            <td class="text-right %s">%+.1f%%</td>
-        </tr>`, escapeHTML(r.Name), r.OldNsPerOp, r.NewNsPerOp, timeClass, r.NsPerOpPct,
-			r.OldBytes, r.NewBytes, memClass, r.BytesPct))
+            <td class="text-right %s">%s</td>
+        </tr>`, escapeHTML(r.Name), formatRuleBadges(r), r.OldNsPerOp, r.NewNsPerOp, timeClass, timeChange,
+			r.OldBytes, r.NewBytes, memClass, memChange))
+
+		for _, m := range r.ExtraMetrics() {
+			class, change := formatDirectedChange(m.Pct, m.Worsening(), m.Sig, r.ThresholdFor(m.Unit, g.threshold))
+			direction := ""
+			if m.HigherIsBetter {
+				direction = " ↑"
+			}
+			rows = append(rows, fmt.Sprintf(`<tr class="metric-row">
+            <td class="metric-unit">└ %s%s</td>
+            <td class="text-right">%s</td>
+            <td class="text-right">%s</td>
+            <td class="text-right %s">%s</td>
+            <td colspan="3"></td>
+        </tr>`, escapeHTML(m.Unit), direction,
+				formatValue(m.Old), formatValue(m.New), class, change))
+		}
 	}
 
 	return fmt.Sprintf(`<section class="comparison-table">
@@ -595,7 +887,113 @@ func (g *Generator) generateComparisonTable() string {
             </tbody>
         </table>
     </div>
-</section>`, joinStrings(rows, "\n"))
+%s
+%s
+%s
+</section>`, joinStrings(rows, "\n"),
+		g.generatePresenceTable("Added Benchmarks", bench.FilterByKind(g.comparison, bench.ComparisonAdded)),
+		g.generatePresenceTable("Removed Benchmarks", bench.FilterByKind(g.comparison, bench.ComparisonRemoved)),
+		g.generateComparisonPivots())
+}
+
+func (g *Generator) generateComparisonPivots() string {
+	var keys []string
+	for _, r := range g.comparison {
+		for k := range r.Params {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	slices.Sort(keys)
+
+	var sections []string
+	for _, param := range keys {
+		table := bench.PivotResults(g.comparison, param)
+		if len(table.Rows) == 0 {
+			continue
+		}
+		sections = append(sections, fmt.Sprintf(`    <h3>Time Δ%% by %s</h3>
+%s`, escapeHTML(param), g.generatePivotTable(table)))
+	}
+
+	return joinStrings(sections, "\n")
+}
+
+func (g *Generator) generatePivotTable(table bench.PivotTable) string {
+	var header []string
+	for _, c := range table.Columns {
+		header = append(header, fmt.Sprintf(`<th class="text-right">%s=%s</th>`, escapeHTML(table.Param), escapeHTML(c)))
+	}
+
+	var rows []string
+	for _, row := range table.Rows {
+		var cells []string
+		for _, c := range table.Columns {
+			v, ok := row.Values[c]
+			switch {
+			case !ok:
+				cells = append(cells, `<td class="text-right">-</td>`)
+			case table.Delta:
+				cells = append(cells, fmt.Sprintf(`<td class="text-right %s">%+.1f%%</td>`, getClassForChange(v, g.threshold), v))
+			default:
+				cells = append(cells, fmt.Sprintf(`<td class="text-right">%s</td>`, formatValue(v)))
+			}
+		}
+		rows = append(rows, fmt.Sprintf(`<tr>
+            <td class="bench-name">%s</td>
+            %s
+        </tr>`, escapeHTML(row.Label), joinStrings(cells, "")))
+	}
+
+	return fmt.Sprintf(`    <div class="table-wrapper">
+        <table>
+            <thead>
+                <tr>
+                    <th>Benchmark</th>
+                    %s
+                </tr>
+            </thead>
+            <tbody>
+%s
+            </tbody>
+        </table>
+    </div>`, joinStrings(header, ""), joinStrings(rows, "\n"))
+}
+
+func (g *Generator) generatePresenceTable(title string, results []bench.ComparisonResult) string {
+	if len(results) == 0 {
+		return ""
+	}
+
+	var rows []string
+	for _, r := range results {
+		nsPerOp, bytes := r.NewNsPerOp, r.NewBytes
+		if r.Kind == bench.ComparisonRemoved {
+			nsPerOp, bytes = r.OldNsPerOp, r.OldBytes
+		}
+		rows = append(rows, fmt.Sprintf(`<tr>
+            <td class="bench-name">%s</td>
+            <td class="text-right">%.0f</td>
+            <td class="text-right">%.0f</td>
+        </tr>`, escapeHTML(r.Name), nsPerOp, bytes))
+	}
+
+	return fmt.Sprintf(`    <h3>%s (%d)</h3>
+    <div class="table-wrapper">
+        <table>
+            <thead>
+                <tr>
+                    <th>Benchmark</th>
+                    <th class="text-right">ns/op</th>
+                    <th class="text-right">B/op</th>
+                </tr>
+            </thead>
+            <tbody>
+%s
+            </tbody>
+        </table>
+    </div>`, title, len(results), joinStrings(rows, "\n"))
 }
 
 func (g *Generator) generateEmptyState(message string) string {
@@ -847,6 +1245,10 @@ h3 {
     font-weight: 500;
 }
 
+.badge-danger {
+    background: #f56565;
+}
+
 .card-body {
     padding: 1rem;
 }
@@ -876,6 +1278,18 @@ h3 {
     gap: 1rem;
 }
 
+.failure-output,
+.benchmark-log {
+    margin-top: 0.75rem;
+    padding: 0.75rem;
+    background: var(--bg-primary);
+    border-radius: 4px;
+    color: var(--text-secondary);
+    font-size: 0.85rem;
+    overflow-x: auto;
+    white-space: pre-wrap;
+}
+
 .metric {
     display: flex;
     gap: 0.5rem;
@@ -929,6 +1343,10 @@ h3 {
     overflow-x: auto;
 }
 
+.comparison-table h3 {
+    margin-top: 1.5rem;
+}
+
 table {
     width: 100%;
     border-collapse: collapse;
@@ -979,6 +1397,22 @@ td.text-right {
     color: var(--neutral);
 }
 
+.metric-row td {
+    padding-top: 0.25rem;
+    padding-bottom: 0.25rem;
+    font-size: 0.8rem;
+}
+
+.metric-unit {
+    color: var(--text-muted);
+    padding-left: 2rem;
+}
+
+.p-value {
+    color: var(--text-muted);
+    font-size: 0.75rem;
+}
+
 /* Empty State */
 .empty-state {
     text-align: center;
@@ -1026,7 +1460,7 @@ td.text-right {
 
 .bar-row {
     display: grid;
-    grid-template-columns: minmax(200px, 300px) 1fr 80px;
+    grid-template-columns: minmax(200px, 300px) 1fr 110px;
     align-items: center;
     gap: 0.75rem;
     padding: 0.25rem 0;
@@ -1261,6 +1695,33 @@ func escapeJS(s string) string {
 	return s
 }
 
+func formatRuleBadges(r bench.ComparisonResult) string {
+	var badges []string
+	if r.Rule != "" {
+		badges = append(badges, fmt.Sprintf(` <span class="badge">%s</span>`, escapeHTML(r.Rule)))
+	}
+	for _, v := range r.BudgetViolations {
+		badges = append(badges, fmt.Sprintf(` <span class="badge badge-danger">over budget: %s</span>`, escapeHTML(v)))
+	}
+	return joinStrings(badges, "")
+}
+
+func formatSignificantChange(pct float64, sig bench.Significance, threshold float64) (string, string) {
+	return formatDirectedChange(pct, pct, sig, threshold)
+}
+
+func formatDirectedChange(pct, worsening float64, sig bench.Significance, threshold float64) (string, string) {
+	if sig.Tested && !sig.Significant {
+		return "change-neutral", fmt.Sprintf(`<span title="p=%.3f">~</span>`, sig.PValue)
+	}
+
+	change := fmt.Sprintf("%+.1f%%", pct)
+	if sig.Tested {
+		change += fmt.Sprintf(` <span class="p-value">(p=%.3f)</span>`, sig.PValue)
+	}
+	return getClassForChange(worsening, threshold), change
+}
+
 func getClassForChange(pct, threshold float64) string {
 	if pct > threshold {
 		return "change-negative"